@@ -106,7 +106,7 @@ func appendTimeFieldValue(dst []byte, t time.Time, format string) []byte {
 	return dst
 }
 
-func appendCaller(dst []byte, callerSkip int, useShortCaller bool) []byte {
+func callerFrame(callerSkip int) runtime.Frame {
 	pc := pcPool.Get().(*programcounter) // nolint: forcetypeassert
 	defer pcPool.Put(pc)
 
@@ -115,10 +115,15 @@ func appendCaller(dst []byte, callerSkip int, useShortCaller bool) []byte {
 		frame, _ = runtime.CallersFrames(pc.PC).Next()
 	}
 
-	return appendCallerFromFrame(dst, frame, useShortCaller)
+	return frame
 }
 
-// appendCallerFromFrame was split off from appendCaller in order to test different behaviors depending on the contents of the `runtime.Frame`.
+// isZeroFrame reports whether the caller is unknown, e.g. `slog.Record` without PC. The caller field is omitted for it.
+func isZeroFrame(frame runtime.Frame) bool {
+	return frame.PC == 0 && frame.File == ""
+}
+
+// appendCallerFromFrame was split off from callerFrame in order to test different behaviors depending on the contents of the `runtime.Frame`.
 func appendCallerFromFrame(dst []byte, frame runtime.Frame, useShortCaller bool) []byte {
 	const base = 10
 
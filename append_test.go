@@ -230,3 +230,11 @@ func Test_appendTimeFieldValue(t *testing.T) {
 		})
 	}
 }
+
+func Test_isZeroFrame(t *testing.T) {
+	t.Parallel()
+
+	FailIfNotEqual(t, true, isZeroFrame(runtime.Frame{}))
+	FailIfNotEqual(t, false, isZeroFrame(runtime.Frame{File: "/a", Line: 10}))
+	FailIfNotEqual(t, false, isZeroFrame(callerFrame(1)))
+}
@@ -0,0 +1,205 @@
+package rec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// AsyncPolicy controls the behavior of `*rec.AsyncWriter` when the queue is full.
+type AsyncPolicy int
+
+const (
+	// AsyncPolicyBlock blocks the caller until the queue has space.
+	AsyncPolicyBlock AsyncPolicy = iota
+	// AsyncPolicyDropNewest drops the log entry being written.
+	AsyncPolicyDropNewest
+	// AsyncPolicyDropOldest drops the oldest log entry in the queue.
+	AsyncPolicyDropOldest
+)
+
+// AsyncWriter is an io.Writer that writes log entries to the underlying io.Writer in a background goroutine.
+//
+// Close or Flush must be called before the program exits, otherwise the log entries in the queue will be lost.
+// Close does not close the underlying io.Writer.
+type AsyncWriter struct {
+	mu     sync.RWMutex
+	closed bool
+
+	writer io.Writer
+	policy AsyncPolicy
+	queue  chan *buffer
+	done   chan struct{}
+
+	dropped uint64
+
+	pendingMu sync.Mutex
+	pending   int
+	waiters   []chan struct{}
+}
+
+// NewAsyncWriter returns `*rec.AsyncWriter` and starts the background goroutine.
+func NewAsyncWriter(writer io.Writer, queueSize int, policy AsyncPolicy) (*AsyncWriter, error) {
+	if queueSize <= 0 {
+		return nil, fmt.Errorf("queueSize=%d: %w", queueSize, ErrInvalidQueueSize)
+	}
+
+	w := &AsyncWriter{
+		writer: writer,
+		policy: policy,
+		queue:  make(chan *buffer, queueSize),
+		done:   make(chan struct{}),
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	for b := range w.queue {
+		w.write(b)
+		w.release()
+	}
+}
+
+func (w *AsyncWriter) write(b *buffer) {
+	defer bufferPool.Put(b)
+
+	if _, err := w.writer.Write(b.Buffer); err != nil {
+		if l := defaultLogger; l.writer != w {
+			err = fmt.Errorf("(*rec.AsyncWriter).write: writer=%#v: Write: %w", w.writer, err)
+			l.write(ERROR, err.Error(), Error(err))
+		}
+	}
+}
+
+// Write copies p and enqueues it.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	b := bufferPool.Get().(*buffer) // nolint: forcetypeassert
+	b.Buffer = append(b.Buffer[:0], p...)
+
+	w.enqueue(b)
+
+	return len(p), nil
+}
+
+// enqueue hands b to the background goroutine. b is put back to bufferPool after it is written or dropped.
+func (w *AsyncWriter) enqueue(b *buffer) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		w.write(b)
+
+		return
+	}
+
+	w.acquire()
+
+	switch w.policy {
+	case AsyncPolicyDropNewest:
+		select {
+		case w.queue <- b:
+		default:
+			w.drop(b)
+		}
+	case AsyncPolicyDropOldest:
+		for {
+			select {
+			case w.queue <- b:
+				return
+			default:
+			}
+
+			select {
+			case oldest := <-w.queue:
+				w.drop(oldest)
+			default:
+			}
+		}
+	case AsyncPolicyBlock:
+		fallthrough
+	default:
+		w.queue <- b
+	}
+}
+
+func (w *AsyncWriter) drop(b *buffer) {
+	bufferPool.Put(b)
+	atomic.AddUint64(&w.dropped, 1)
+	w.release()
+}
+
+func (w *AsyncWriter) acquire() {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+
+	w.pending++
+}
+
+func (w *AsyncWriter) release() {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+
+	w.pending--
+
+	if w.pending == 0 {
+		for _, waiter := range w.waiters {
+			close(waiter)
+		}
+
+		w.waiters = nil
+	}
+}
+
+// Dropped returns the number of log entries dropped because the queue was full.
+func (w *AsyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Flush waits until all log entries in the queue are written to the underlying io.Writer, or ctx is done.
+func (w *AsyncWriter) Flush(ctx context.Context) error {
+	w.pendingMu.Lock()
+
+	if w.pending == 0 {
+		w.pendingMu.Unlock()
+
+		return nil
+	}
+
+	waiter := make(chan struct{})
+	w.waiters = append(w.waiters, waiter)
+	w.pendingMu.Unlock()
+
+	select {
+	case <-waiter:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("(*rec.AsyncWriter).Flush: %w", ctx.Err())
+	}
+}
+
+// Close writes all log entries in the queue to the underlying io.Writer and stops the background goroutine.
+// After Close, log entries are written synchronously.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+
+	if w.closed {
+		w.mu.Unlock()
+
+		return nil
+	}
+
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter blocks Write until release is closed.
+type blockingWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.started) })
+	<-w.release
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.String()
+}
+
+func TestNewAsyncWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error(queueSize)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewAsyncWriter(devnull, 0, AsyncPolicyBlock)
+		FailIfNotErrorIs(t, ErrInvalidQueueSize, err)
+	})
+}
+
+func TestAsyncWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(AsyncPolicyBlock)", func(t *testing.T) {
+		t.Parallel()
+
+		w := newBlockingWriter()
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false), WithAsync(1, AsyncPolicyBlock)))
+		t.Cleanup(func() { _ = l.Close() })
+
+		l.Info("0")
+		<-w.started
+		l.Info("1")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		FailIfNotErrorIs(t, context.DeadlineExceeded, l.Flush(ctx))
+
+		close(w.release)
+		if err := l.Flush(context.Background()); err != nil {
+			t.Errorf("Flush: %v", err)
+		}
+
+		const expect = `{"severity":"INFO","message":"0"}` + defaultLineSeparator + `{"severity":"INFO","message":"1"}` + defaultLineSeparator
+		FailIfNotEqual(t, expect, w.String())
+		FailIfNotEqual(t, uint64(0), l.Dropped())
+	})
+
+	t.Run("success(AsyncPolicyDropNewest)", func(t *testing.T) {
+		t.Parallel()
+
+		w := newBlockingWriter()
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false), WithAsync(1, AsyncPolicyDropNewest)))
+
+		l.Info("0")
+		<-w.started
+		l.Info("1")
+		l.Info("2")
+
+		close(w.release)
+		if err := l.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+
+		const expect = `{"severity":"INFO","message":"0"}` + defaultLineSeparator + `{"severity":"INFO","message":"1"}` + defaultLineSeparator
+		FailIfNotEqual(t, expect, w.String())
+		FailIfNotEqual(t, uint64(1), l.Dropped())
+	})
+
+	t.Run("success(AsyncPolicyDropOldest)", func(t *testing.T) {
+		t.Parallel()
+
+		w := newBlockingWriter()
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false), WithAsync(1, AsyncPolicyDropOldest)))
+
+		l.Info("0")
+		<-w.started
+		l.Info("1")
+		l.Info("2")
+
+		close(w.release)
+		if err := l.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+
+		const expect = `{"severity":"INFO","message":"0"}` + defaultLineSeparator + `{"severity":"INFO","message":"2"}` + defaultLineSeparator
+		FailIfNotEqual(t, expect, w.String())
+		FailIfNotEqual(t, uint64(1), l.Dropped())
+	})
+
+	t.Run("success(Write,Close)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		w, err := NewAsyncWriter(buf, 8, AsyncPolicyBlock)
+		if err != nil {
+			t.Fatalf("NewAsyncWriter: %v", err)
+		}
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+		for i := 0; i < 100; i++ {
+			l.Info("test")
+		}
+
+		if _, err := w.Write([]byte("raw\n")); err != nil {
+			t.Errorf("Write: %v", err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+
+		// written synchronously after Close
+		l.Info("closed")
+
+		actual := buf.String()
+		FailIfNotEqual(t, 100, strings.Count(actual, `{"severity":"INFO","message":"test"}`))
+		FailIfNotEqual(t, true, strings.HasSuffix(actual, "raw\n"+`{"severity":"INFO","message":"closed"}`+defaultLineSeparator))
+	})
+
+	t.Run("success(Renew,RenewWriter)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(devnull))
+		renewed := Must(l.Renew(WithAsync(1, AsyncPolicyBlock)))
+		t.Cleanup(func() { _ = renewed.Close() })
+
+		if _, ok := renewed.writer.(*AsyncWriter); !ok {
+			t.Errorf("writer is not *AsyncWriter: %T", renewed.writer)
+		}
+
+		rewritten := renewed.RenewWriter(devnull)
+		t.Cleanup(func() { _ = rewritten.Close() })
+
+		if _, ok := rewritten.writer.(*AsyncWriter); !ok {
+			t.Errorf("writer is not *AsyncWriter: %T", rewritten.writer)
+		}
+
+		FailIfNotEqual(t, renewed.writer, renewed.With(String("field", "value")).writer)
+	})
+}
@@ -0,0 +1,75 @@
+package rec
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the interface to get the current time for the timestamp of the log entries.
+// Set a fixed or fake clock by WithClock to make the output reproducible, e.g. for golden file tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// FixedClock is rec.Clock that always returns the same time.
+type FixedClock struct {
+	t time.Time
+}
+
+// NewFixedClock returns `*rec.FixedClock` that always returns t.
+func NewFixedClock(t time.Time) *FixedClock {
+	return &FixedClock{t: t}
+}
+
+// Now returns the fixed time.
+func (c *FixedClock) Now() time.Time {
+	return c.t
+}
+
+// FakeClock is rec.Clock that returns the time advanced by the step each time Now is called. It is safe for concurrent use.
+type FakeClock struct {
+	mu   sync.Mutex
+	t    time.Time
+	step time.Duration
+}
+
+// NewFakeClock returns `*rec.FakeClock` that returns start first, and then start + step, start + 2*step, and so on.
+func NewFakeClock(start time.Time, step time.Duration) *FakeClock {
+	return &FakeClock{t: start, step: step}
+}
+
+// Now returns the current time of the clock, and advances the clock by the step.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.t
+	c.t = c.t.Add(c.step)
+
+	return now
+}
+
+// Add advances the clock by d.
+func (c *FakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.t = c.t.Add(d)
+}
+
+// Set sets the current time of the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.t = t
+}
+
+// now returns the current time by `config.Clock`. If `config.Clock` is nil, time.Now is used.
+func (l *Logger) now() time.Time {
+	if l.config.Clock == nil {
+		return time.Now()
+	}
+
+	return l.config.Clock.Now()
+}
@@ -0,0 +1,112 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testClockTime = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestFixedClock(t *testing.T) {
+	t.Parallel()
+
+	c := NewFixedClock(testClockTime)
+	FailIfNotEqual(t, testClockTime, c.Now())
+	FailIfNotEqual(t, testClockTime, c.Now())
+}
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewFakeClock(testClockTime, time.Second)
+		FailIfNotEqual(t, testClockTime, c.Now())
+		FailIfNotEqual(t, testClockTime.Add(time.Second), c.Now())
+
+		c.Add(time.Minute)
+		FailIfNotEqual(t, testClockTime.Add(time.Minute+2*time.Second), c.Now())
+
+		c.Set(testClockTime)
+		FailIfNotEqual(t, testClockTime, c.Now())
+	})
+
+	t.Run("success(Concurrent)", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewFakeClock(testClockTime, time.Millisecond)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = c.Now()
+			}()
+		}
+		wg.Wait()
+
+		FailIfNotEqual(t, testClockTime.Add(100*time.Millisecond), c.Now())
+	})
+}
+
+func TestLogger_now(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(FakeClock)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseCallerField(false), WithClock(NewFakeClock(testClockTime, time.Second))))
+
+		l.Print(INFO, "Print")
+		l.Info("Info")
+		l.F().Printf(INFO, "F().%s", "Printf")
+		l.E().Print(ERROR, errForTest)
+		l.InfoContext(context.Background(), "InfoContext")
+		_, _ = io.WriteString(l, "Write")
+
+		const expect = `{"timestamp":"2023-01-02T03:04:05Z","severity":"INFO","message":"Print"}` + defaultLineSeparator +
+			`{"timestamp":"2023-01-02T03:04:06Z","severity":"INFO","message":"Info"}` + defaultLineSeparator +
+			`{"timestamp":"2023-01-02T03:04:07Z","severity":"INFO","message":"F().Printf"}` + defaultLineSeparator +
+			`{"timestamp":"2023-01-02T03:04:08Z","severity":"ERROR","message":"test error","error":"test error","errorStacktrace":"test error"}` + defaultLineSeparator +
+			`{"timestamp":"2023-01-02T03:04:09Z","severity":"INFO","message":"InfoContext"}` + defaultLineSeparator +
+			`{"timestamp":"2023-01-02T03:04:10Z","severity":"DEFAULT","message":"Write"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(SeverityThreshold)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseCallerField(false), WithSeverityThreshold(INFO), WithClock(NewFakeClock(testClockTime, time.Second))))
+
+		// NOTE: the clock is not read for the filtered log entries.
+		l.Debug("filtered")
+		l.F().Debugf("%s", "filtered")
+		l.E().Debug(errForTest)
+		l.Info("first")
+		l.Debug("filtered")
+		l.Info("second")
+
+		const expect = `{"timestamp":"2023-01-02T03:04:05Z","severity":"INFO","message":"first"}` + defaultLineSeparator +
+			`{"timestamp":"2023-01-02T03:04:06Z","severity":"INFO","message":"second"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(time.Now)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(io.Discard))
+		begin := time.Now()
+		FailIfNotEqual(t, false, l.now().Before(begin))
+	})
+}
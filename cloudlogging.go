@@ -0,0 +1,148 @@
+package rec
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	cloudLoggingTimestampFieldKey   = "time"
+	cloudLoggingSeverityFieldKey    = "severity"
+	cloudLoggingMessageFieldKey     = "message"
+	cloudLoggingSourceLocationKey   = "logging.googleapis.com/sourceLocation"
+	cloudLoggingLabelsFieldKey      = "logging.googleapis.com/labels"
+	cloudLoggingHTTPRequestFieldKey = "httpRequest"
+)
+
+// WithCloudLoggingProfile returns `rec.Option` for setting the config to output the structured logs that Google Cloud Logging parses natively.
+//
+//	{"time":"...","severity":"INFO","logging.googleapis.com/sourceLocation":{"file":"main.go","line":"10","function":"main.main"},"message":"..."}
+//
+// The trace fields are set by WithCloudLoggingTraceFieldKeys(projectID). Use WithTraceExtractor to extract the trace from context.Context.
+// Use CloudLoggingLabels and CloudLoggingHTTPRequestField to add the labels and httpRequest fields.
+//
+// cf. https://cloud.google.com/logging/docs/structured-logging
+func WithCloudLoggingProfile(projectID string) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			if err := WithCloudLoggingTraceFieldKeys(projectID).f(config); err != nil {
+				return err
+			}
+
+			config.Format = FormatJSON
+			config.UseTimestampField = true
+			config.TimestampFieldKey = cloudLoggingTimestampFieldKey
+			config.TimestampFieldFormat = time.RFC3339Nano
+			config.UseSeverityField = true
+			config.SeverityFieldKey = cloudLoggingSeverityFieldKey
+			config.UseUppercaseSeverity = true
+			config.UseCallerField = true
+			config.CallerFieldKey = cloudLoggingSourceLocationKey
+			config.CallerFieldFormat = CallerFormatSourceLocation
+			config.UseMessageField = true
+			config.MessageFieldKey = cloudLoggingMessageFieldKey
+
+			return nil
+		},
+	}
+}
+
+type cloudLoggingLabels map[string]string
+
+func (labels cloudLoggingLabels) MarshalRecObject(enc *ObjectEncoder) {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		enc.AddString(key, labels[key])
+	}
+}
+
+// CloudLoggingLabels returns rec.Field for `logging.googleapis.com/labels` of Google Cloud Logging.
+func CloudLoggingLabels(labels map[string]string) Field {
+	return Marshaler(cloudLoggingLabelsFieldKey, cloudLoggingLabels(labels))
+}
+
+// CloudLoggingHTTPRequest is HttpRequest of Google Cloud Logging. The zero value fields are omitted.
+//
+// cf. https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry#HttpRequest
+type CloudLoggingHTTPRequest struct {
+	RequestMethod string
+	RequestURL    string
+	RequestSize   int64
+	Status        int
+	ResponseSize  int64
+	UserAgent     string
+	RemoteIP      string
+	ServerIP      string
+	Referer       string
+	Latency       time.Duration
+	Protocol      string
+}
+
+// MarshalRecObject implements `rec.ObjectMarshaler`.
+// nolint: cyclop
+func (r *CloudLoggingHTTPRequest) MarshalRecObject(enc *ObjectEncoder) {
+	const base = 10
+
+	if r.RequestMethod != "" {
+		enc.AddString("requestMethod", r.RequestMethod)
+	}
+
+	if r.RequestURL != "" {
+		enc.AddString("requestUrl", r.RequestURL)
+	}
+
+	// NOTE: int64 is string in JSON of Google Cloud Logging.
+	if r.RequestSize != 0 {
+		enc.AddString("requestSize", strconv.FormatInt(r.RequestSize, base))
+	}
+
+	if r.Status != 0 {
+		enc.AddInt64("status", int64(r.Status))
+	}
+
+	if r.ResponseSize != 0 {
+		enc.AddString("responseSize", strconv.FormatInt(r.ResponseSize, base))
+	}
+
+	if r.UserAgent != "" {
+		enc.AddString("userAgent", r.UserAgent)
+	}
+
+	if r.RemoteIP != "" {
+		enc.AddString("remoteIp", r.RemoteIP)
+	}
+
+	if r.ServerIP != "" {
+		enc.AddString("serverIp", r.ServerIP)
+	}
+
+	if r.Referer != "" {
+		enc.AddString("referer", r.Referer)
+	}
+
+	// NOTE: Duration is a string like "3.5s" in JSON of Google Cloud Logging.
+	if r.Latency != 0 {
+		enc.AddString("latency", strconv.FormatFloat(r.Latency.Seconds(), 'f', -1, 64)+"s")
+	}
+
+	if r.Protocol != "" {
+		enc.AddString("protocol", r.Protocol)
+	}
+}
+
+// CloudLoggingHTTPRequestField returns rec.Field for `httpRequest` of Google Cloud Logging.
+func CloudLoggingHTTPRequestField(r *CloudLoggingHTTPRequest) Field {
+	if r == nil {
+		return Marshaler(cloudLoggingHTTPRequestFieldKey, nil)
+	}
+
+	return Marshaler(cloudLoggingHTTPRequestFieldKey, r)
+}
@@ -0,0 +1,90 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestWithCloudLoggingProfile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithCloudLoggingProfile("my-project"), WithTraceExtractor(testTraceExtractor)))
+
+		ctx := context.WithValue(context.Background(), testTraceKey{}, Trace{TraceID: "abc", SpanID: "def", Sampled: true})
+		l.With(CloudLoggingLabels(map[string]string{"b": "2", "a": "1"})).InfoContext(ctx, "test", CloudLoggingHTTPRequestField(&CloudLoggingHTTPRequest{
+			RequestMethod: "GET",
+			RequestURL:    "https://example.com/path",
+			RequestSize:   100,
+			Status:        200,
+			ResponseSize:  200,
+			UserAgent:     "test-agent",
+			RemoteIP:      "192.0.2.1",
+			ServerIP:      "192.0.2.2",
+			Referer:       "https://example.com/",
+			Latency:       1500 * time.Millisecond,
+			Protocol:      "HTTP/1.1",
+		}))
+
+		expect := regexp.MustCompile(`^{"time":"[^"]+","severity":"INFO","logging.googleapis.com/sourceLocation":{"file":"[^"]+/cloudlogging_test.go","line":"[0-9]+","function":"github.com/kunitsuinc/rec%2ego.TestWithCloudLoggingProfile.func1"},"message":"test",` +
+			`"logging.googleapis.com/labels":{"a":"1","b":"2"},` +
+			`"logging.googleapis.com/trace":"projects/my-project/traces/abc","logging.googleapis.com/spanId":"def","logging.googleapis.com/trace_sampled":true,` +
+			`"httpRequest":{"requestMethod":"GET","requestUrl":"https://example.com/path","requestSize":"100","status":200,"responseSize":"200","userAgent":"test-agent","remoteIp":"192.0.2.1","serverIp":"192.0.2.2","referer":"https://example.com/","latency":"1.5s","protocol":"HTTP/1.1"}}` + defaultLineSeparator + `$`)
+		actual := buf.String()
+		FailIfNotRegexpMatchString(t, expect, actual)
+	})
+
+	t.Run("success(EmptyHTTPRequest)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithCloudLoggingProfile("my-project"), WithUseTimestampField(false), WithUseCallerField(false)))
+		l.Info("test", CloudLoggingHTTPRequestField(&CloudLoggingHTTPRequest{}), CloudLoggingHTTPRequestField(nil))
+
+		const expect = `{"severity":"INFO","message":"test","httpRequest":{},"httpRequest":null}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("error(projectID)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(bytes.NewBuffer(nil), WithCloudLoggingProfile(""))
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+	})
+}
+
+func Test_appendJSONCaller(t *testing.T) {
+	t.Parallel()
+
+	frame := runtime.Frame{File: "/path/to/rec.go/file.go", Line: 10, Function: "rec.Function"}
+
+	tests := []struct {
+		name           string
+		format         CallerFormat
+		useShortCaller bool
+		expect         string
+	}{
+		{"success(String)", CallerFormatString, true, `"rec.go/file.go:10"`},
+		{"success(Empty)", "", false, `"/path/to/rec.go/file.go:10"`},
+		{"success(SourceLocation)", CallerFormatSourceLocation, false, `{"file":"/path/to/rec.go/file.go","line":"10","function":"rec.Function"}`},
+		{"success(ECS)", CallerFormatECS, true, `{"file":{"name":"rec.go/file.go","line":10},"function":"rec.Function"}`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := string(appendJSONCaller(nil, frame, tt.format, tt.useShortCaller))
+			FailIfNotEqual(t, tt.expect, actual)
+		})
+	}
+}
@@ -12,6 +12,40 @@ const (
 	defaultLineSeparator      = "\n"
 )
 
+// Format is the output format of *rec.Logger.
+type Format string
+
+const (
+	// FormatJSON outputs log entries as JSON (NDJSON, JSONLines, JSONL).
+	FormatJSON Format = "json"
+	// FormatConsole outputs log entries in human-readable format for local development.
+	FormatConsole Format = "console"
+	// FormatLogfmt outputs log entries as logfmt.
+	FormatLogfmt Format = "logfmt"
+)
+
+// CallerFormat is the format of the caller field in FormatJSON.
+type CallerFormat string
+
+const (
+	// CallerFormatString outputs the caller field as a string like `"file:line"`.
+	CallerFormatString CallerFormat = "string"
+	// CallerFormatSourceLocation outputs the caller field as an object like `{"file":"...","line":"...","function":"..."}`, that is LogEntrySourceLocation of Google Cloud Logging.
+	CallerFormatSourceLocation CallerFormat = "sourceLocation"
+	// CallerFormatECS outputs the caller field as an object like `{"file":{"name":"...","line":10},"function":"..."}`, that is `log.origin` of Elastic Common Schema.
+	CallerFormatECS CallerFormat = "ecs"
+)
+
+// ErrorFormat is the format of the error fields in FormatJSON.
+type ErrorFormat string
+
+const (
+	// ErrorFormatString outputs rec.Error and rec.ErrorStacktrace as a string.
+	ErrorFormatString ErrorFormat = "string"
+	// ErrorFormatECS outputs rec.Error and rec.ErrorStacktrace as an object like `{"message":"...","type":"...","stack_trace":"..."}`, that is `error` of Elastic Common Schema.
+	ErrorFormatECS ErrorFormat = "ecs"
+)
+
 // Config is configuration struct for *rec.Logger.
 type Config struct {
 	// [timestamp] Set true if you want to output the timestamp field in the log.
@@ -27,6 +61,8 @@ type Config struct {
 	SeverityFieldKey string
 	// [severity] Set the severity of the log output.
 	SeverityThreshold Severity
+	// [severity] Set the severity threshold that can be changed at runtime. If set, SeverityThreshold is ignored.
+	AtomicSeverityThreshold *AtomicSeverity
 	// [severity] Set true if you want to output severity in uppercase.
 	UseUppercaseSeverity bool
 	// [severity] default severity for io.Writer
@@ -47,6 +83,8 @@ type Config struct {
 	CallerSkip int
 	// [caller]
 	UseShortCaller bool
+	// [caller] Set the format of the caller field in FormatJSON.
+	CallerFieldFormat CallerFormat
 
 	// [message]
 	UseMessageField bool
@@ -55,6 +93,54 @@ type Config struct {
 
 	// [lineseparator]
 	LineSeparator string
+
+	// [error] Set the format of rec.Error and rec.ErrorStacktrace fields in FormatJSON.
+	ErrorFieldFormat ErrorFormat
+
+	// [ecs] Set the value in the `ecs.version` field. If empty, the field is omitted.
+	ECSVersion string
+
+	// [redact] Set `*rec.Redactor` to redact the sensitive values of the fields and the message.
+	Redactor *Redactor
+
+	// [sensitive] Set the policy to render the values of rec.Secret fields. Default is SensitivePolicyMask.
+	SecretFieldPolicy SensitivePolicy
+	// [sensitive] Set the policy to render the values of rec.PII fields. Default is SensitivePolicyMask.
+	PIIFieldPolicy SensitivePolicy
+	// [sensitive] Set the salt for SensitivePolicyHash. It is required if SensitivePolicyHash is used.
+	SensitiveFieldHashSalt string
+
+	// [clock] Set rec.Clock to get the timestamp of the log entries, the latency of the HTTP middleware and the expiry of the SeverityHandler.
+	// If nil, time.Now is used. The clock is not read for the log entries below SeverityThreshold.
+	Clock Clock
+
+	// [trace] Set `rec.TraceExtractor` to add the trace fields by the context-aware logging methods such as InfoContext.
+	TraceExtractor TraceExtractor
+	// [trace] Set the key name in the trace ID field. If empty, the field is omitted.
+	TraceIDFieldKey string
+	// [trace] Set the prefix of the value in the trace ID field, e.g. `projects/PROJECT_ID/traces/` for Cloud Logging.
+	TraceIDFieldValuePrefix string
+	// [trace] Set the key name in the span ID field. If empty, the field is omitted.
+	SpanIDFieldKey string
+	// [trace] Set the key name in the trace sampled field. If empty, the field is omitted.
+	TraceSampledFieldKey string
+
+	// [format] Set the output format of the log entry.
+	Format Format
+	// [format] Set true if you want to colorize severity in FormatConsole. Default is false, because ANSI escape codes break files and pipes.
+	UseColor bool
+
+	// [async] Set the queue size to write log entries asynchronously. If 0, log entries are written synchronously.
+	AsyncQueueSize int
+	// [async] Set the behavior when the queue is full.
+	AsyncPolicy AsyncPolicy
+
+	// [sampling] Set the interval to reset the sampling counters. If 0, log entries are not sampled.
+	SamplingTick time.Duration
+	// [sampling] Set the number of log entries per severity and message to write per tick.
+	SamplingFirst int
+	// [sampling] Set N to write every Nth log entry after SamplingFirst per tick. If 0, all log entries after SamplingFirst are dropped.
+	SamplingThereafter int
 }
 
 // NewConfig returns *rec.Config that set default values.
@@ -69,25 +155,55 @@ func newConfig(osHostname func() (string, error)) *Config {
 		TimestampFieldKey:    "timestamp",
 		TimestampFieldFormat: time.RFC3339Nano,
 		// "severity":"...",
-		UseSeverityField:     true,
-		SeverityFieldKey:     "severity",
-		SeverityThreshold:    DEFAULT,
-		UseUppercaseSeverity: true,
-		DefaultSeverity:      DEFAULT,
+		UseSeverityField:        true,
+		SeverityFieldKey:        "severity",
+		SeverityThreshold:       DEFAULT,
+		AtomicSeverityThreshold: nil,
+		UseUppercaseSeverity:    true,
+		DefaultSeverity:         DEFAULT,
 		// "hostname":"...",
 		UseHostnameField:   false,
 		HostnameFieldKey:   "hostname",
 		HostnameFieldValue: defaultHostnameFieldValue,
 		// "caller":"...",
-		UseCallerField: true,
-		CallerFieldKey: "caller",
-		CallerSkip:     defaultCallerSkip,
-		UseShortCaller: true,
+		UseCallerField:    true,
+		CallerFieldKey:    "caller",
+		CallerSkip:        defaultCallerSkip,
+		UseShortCaller:    true,
+		CallerFieldFormat: CallerFormatString,
 		// "message":"...",
 		UseMessageField: true,
 		MessageFieldKey: "message",
 		// \n
 		LineSeparator: defaultLineSeparator,
+		// error
+		ErrorFieldFormat: ErrorFormatString,
+		// ecs
+		ECSVersion: "",
+		// redact
+		Redactor: nil,
+		// sensitive
+		SecretFieldPolicy:      SensitivePolicyMask,
+		PIIFieldPolicy:         SensitivePolicyMask,
+		SensitiveFieldHashSalt: "",
+		// clock
+		Clock: nil,
+		// trace
+		TraceExtractor:          nil,
+		TraceIDFieldKey:         defaultTraceIDFieldKey,
+		TraceIDFieldValuePrefix: "",
+		SpanIDFieldKey:          defaultSpanIDFieldKey,
+		TraceSampledFieldKey:    defaultTraceSampledFieldKey,
+		// format
+		Format:   FormatJSON,
+		UseColor: false,
+		// async
+		AsyncQueueSize: 0,
+		AsyncPolicy:    AsyncPolicyBlock,
+		// sampling
+		SamplingTick:       0,
+		SamplingFirst:      0,
+		SamplingThereafter: 0,
 	}
 
 	var err error
@@ -117,5 +233,40 @@ func (c *Config) validate() error {
 		return fmt.Errorf("*Config.MessageFieldKey %w", ErrIsEmpty)
 	}
 
+	switch c.Format {
+	case "", FormatJSON, FormatConsole, FormatLogfmt:
+	default:
+		return fmt.Errorf("*Config.Format=%s: %w", c.Format, ErrUnknownFormat)
+	}
+
+	switch c.CallerFieldFormat {
+	case "", CallerFormatString, CallerFormatSourceLocation, CallerFormatECS:
+	default:
+		return fmt.Errorf("*Config.CallerFieldFormat=%s: %w", c.CallerFieldFormat, ErrUnknownFormat)
+	}
+
+	switch c.ErrorFieldFormat {
+	case "", ErrorFormatString, ErrorFormatECS:
+	default:
+		return fmt.Errorf("*Config.ErrorFieldFormat=%s: %w", c.ErrorFieldFormat, ErrUnknownFormat)
+	}
+
+	if !c.SecretFieldPolicy.valid() {
+		return fmt.Errorf("*Config.SecretFieldPolicy=%s: %w", c.SecretFieldPolicy, ErrUnknownSensitivePolicy)
+	}
+
+	if !c.PIIFieldPolicy.valid() {
+		return fmt.Errorf("*Config.PIIFieldPolicy=%s: %w", c.PIIFieldPolicy, ErrUnknownSensitivePolicy)
+	}
+
+	if (c.SecretFieldPolicy == SensitivePolicyHash || c.PIIFieldPolicy == SensitivePolicyHash) && c.SensitiveFieldHashSalt == "" {
+		// NOTE: SHA-256 without salt is easily reversed for the values that have low entropy, e.g. email addresses, phone numbers.
+		return fmt.Errorf("*Config.SensitiveFieldHashSalt %w", ErrIsEmpty)
+	}
+
+	if c.SamplingTick < 0 || c.SamplingFirst < 0 || c.SamplingThereafter < 0 {
+		return fmt.Errorf("*Config.SamplingTick=%s, *Config.SamplingFirst=%d, *Config.SamplingThereafter=%d: %w", c.SamplingTick, c.SamplingFirst, c.SamplingThereafter, ErrInvalidSampling)
+	}
+
 	return nil
 }
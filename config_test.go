@@ -4,6 +4,7 @@ package rec
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -83,6 +84,25 @@ func TestConfig_validate(t *testing.T) {
 	configNGMessageFieldKey := NewConfig()
 	configNGMessageFieldKey.MessageFieldKey = ""
 
+	configNGFormat := NewConfig()
+	configNGFormat.Format = "unknown"
+	configNGCallerFieldFormat := NewConfig()
+	configNGCallerFieldFormat.CallerFieldFormat = "unknown"
+	configNGErrorFieldFormat := NewConfig()
+	configNGErrorFieldFormat.ErrorFieldFormat = "unknown"
+	configNGSecretFieldPolicy := NewConfig()
+	configNGSecretFieldPolicy.SecretFieldPolicy = "unknown"
+	configNGPIIFieldPolicy := NewConfig()
+	configNGPIIFieldPolicy.PIIFieldPolicy = "unknown"
+	configNGSensitiveFieldHashSalt := NewConfig()
+	configNGSensitiveFieldHashSalt.PIIFieldPolicy = SensitivePolicyHash
+	configOKSensitiveFieldHashSalt := NewConfig()
+	configOKSensitiveFieldHashSalt.PIIFieldPolicy = SensitivePolicyHash
+	configOKSensitiveFieldHashSalt.SensitiveFieldHashSalt = "salt"
+	configNGSampling := NewConfig()
+	configNGSampling.SamplingTick = time.Second
+	configNGSampling.SamplingFirst = -1
+
 	tests := []struct {
 		name      string
 		config    *Config
@@ -97,6 +117,14 @@ func TestConfig_validate(t *testing.T) {
 		{"error(CallerFieldKey)", configNGCallerFieldKey, ErrIsEmpty},
 		{"success(MessageFieldKey)", configOKMessageFieldKey, nil},
 		{"error(MessageFieldKey)", configNGMessageFieldKey, ErrIsEmpty},
+		{"error(Format)", configNGFormat, ErrUnknownFormat},
+		{"error(CallerFieldFormat)", configNGCallerFieldFormat, ErrUnknownFormat},
+		{"error(ErrorFieldFormat)", configNGErrorFieldFormat, ErrUnknownFormat},
+		{"error(SecretFieldPolicy)", configNGSecretFieldPolicy, ErrUnknownSensitivePolicy},
+		{"error(PIIFieldPolicy)", configNGPIIFieldPolicy, ErrUnknownSensitivePolicy},
+		{"success(SensitiveFieldHashSalt)", configOKSensitiveFieldHashSalt, nil},
+		{"error(SensitiveFieldHashSalt)", configNGSensitiveFieldHashSalt, ErrIsEmpty},
+		{"error(Sampling)", configNGSampling, ErrInvalidSampling},
 	}
 	for _, tt := range tests {
 		tt := tt
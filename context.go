@@ -9,6 +9,7 @@ type contextKey int
 const (
 	_ contextKey = iota
 	key
+	fieldsKey
 )
 
 // ContextLogger returns *rec.Logger that the context has.
@@ -27,3 +28,47 @@ func ContextLogger(ctx context.Context) *Logger {
 func ContextWithLogger(parent context.Context, l *Logger) context.Context {
 	return context.WithValue(parent, key, l)
 }
+
+// ContextWithFields returns context.Context that has rec.Fields added to the fields that parent has.
+// The fields are output by the context-aware logging methods such as InfoContext.
+func ContextWithFields(parent context.Context, fields ...Field) context.Context {
+	parentFields := ContextFields(parent)
+
+	// NOTE: copy so that the fields of parent are not modified by append.
+	merged := make([]Field, 0, len(parentFields)+len(fields))
+	merged = append(merged, parentFields...)
+	merged = append(merged, fields...)
+
+	return context.WithValue(parent, fieldsKey, merged)
+}
+
+// ContextFields returns rec.Fields that the context has.
+func ContextFields(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+
+	fields, _ := ctx.Value(fieldsKey).([]Field)
+
+	return fields
+}
+
+// appendContextFields returns the trace fields and the fields that ctx has, followed by fields.
+func (l *Logger) appendContextFields(ctx context.Context, severity Severity, fields []Field) []Field {
+	if severity < l.severityThreshold() {
+		return fields
+	}
+
+	contextFields := ContextFields(ctx)
+	if len(contextFields) == 0 && l.config.TraceExtractor == nil {
+		return fields
+	}
+
+	const traceFields = 3
+
+	merged := make([]Field, 0, traceFields+len(contextFields)+len(fields))
+	merged = l.appendTraceFields(merged, ctx)
+	merged = append(merged, contextFields...)
+
+	return append(merged, fields...)
+}
@@ -31,3 +31,17 @@ func TestContextGet(t *testing.T) {
 		})
 	}
 }
+
+func TestContextWithFields(t *testing.T) {
+	t.Parallel()
+
+	parent := rec.ContextWithFields(context.Background(), rec.String("parent", "value"))
+	child1 := rec.ContextWithFields(parent, rec.String("child", "1"))
+	child2 := rec.ContextWithFields(parent, rec.String("child", "2"))
+
+	rec.FailIfNotEqual(t, 0, len(rec.ContextFields(context.Background())))
+	rec.FailIfNotEqual(t, 1, len(rec.ContextFields(parent)))
+	rec.FailIfNotDeepEqual(t, []rec.Field{rec.String("parent", "value"), rec.String("child", "1")}, rec.ContextFields(child1))
+	rec.FailIfNotDeepEqual(t, []rec.Field{rec.String("parent", "value"), rec.String("child", "2")}, rec.ContextFields(child2))
+	rec.FailIfNotEqual(t, 0, len(rec.ContextFields(nil))) // nolint: staticcheck
+}
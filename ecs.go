@@ -0,0 +1,153 @@
+package rec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultECSVersion is the version of Elastic Common Schema set by WithECSProfile.
+	DefaultECSVersion = "8.11.0"
+
+	ecsTimestampFieldKey = "@timestamp"
+	ecsSeverityFieldKey  = "log.level"
+	ecsCallerFieldKey    = "log.origin"
+	ecsMessageFieldKey   = "message"
+	ecsVersionFieldKey   = "ecs.version"
+)
+
+// WithECSProfile returns `rec.Option` for setting the config to output the logs in Elastic Common Schema (ECS).
+//
+//	{"@timestamp":"...","log.level":"info","log.origin":{"file":{"name":"main.go","line":10},"function":"main.main"},"message":"...","ecs.version":"8.11.0"}
+//
+// rec.Error and rec.ErrorStacktrace are output as `{"error":{"message":"...","type":"...","stack_trace":"..."}}`.
+// rec.Error and rec.ErrorStacktrace of the same key, e.g. added by E(), are merged into one `error` object, even if one of them is added by With.
+//
+// cf. https://www.elastic.co/guide/en/ecs/current/ecs-field-reference.html
+func WithECSProfile() Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.Format = FormatJSON
+			config.UseTimestampField = true
+			config.TimestampFieldKey = ecsTimestampFieldKey
+			config.TimestampFieldFormat = time.RFC3339Nano
+			config.UseSeverityField = true
+			config.SeverityFieldKey = ecsSeverityFieldKey
+			config.UseUppercaseSeverity = false
+			config.UseCallerField = true
+			config.CallerFieldKey = ecsCallerFieldKey
+			config.CallerFieldFormat = CallerFormatECS
+			config.UseMessageField = true
+			config.MessageFieldKey = ecsMessageFieldKey
+			config.ErrorFieldFormat = ErrorFormatECS
+			config.ECSVersion = DefaultECSVersion
+
+			return nil
+		},
+	}
+}
+
+// isECSErrorField reports whether the field is encoded as ECS error object in ErrorFormatECS.
+func isECSErrorField(f Field) bool {
+	return f.t == typeError || f.t == typeErrorStacktrace
+}
+
+// ecsErrorFieldKey returns the key of the ECS error object. The default key of rec.ErrorStacktrace is replaced with `error`.
+func ecsErrorFieldKey(f Field) string {
+	if f.t == typeErrorStacktrace && f.key == errorStacktraceKey {
+		return errorKey
+	}
+
+	return f.key
+}
+
+// isMergedECSErrorField reports whether fields[i] is rec.Error that is merged into rec.ErrorStacktrace of the same key in fields.
+func isMergedECSErrorField(fields []Field, i int) bool {
+	if fields[i].t != typeError {
+		return false
+	}
+
+	key := ecsErrorFieldKey(fields[i])
+
+	for j := range fields {
+		if fields[j].t == typeErrorStacktrace && ecsErrorFieldKey(fields[j]) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergesContextECSErrorField reports whether fields has rec.Error or rec.ErrorStacktrace that is merged with the other one of the same key added by With.
+// Only the fields added by With in the same namespace as fields are merged.
+func (l *Logger) mergesContextECSErrorField(fields []Field) bool {
+	for i := range fields {
+		if !isECSErrorField(fields[i]) {
+			continue
+		}
+
+		key := ecsErrorFieldKey(fields[i])
+
+		for j := range l.contextFieldList {
+			f := l.contextFieldList[j] // copy
+			if !isECSErrorField(f) || f.t == fields[i].t || !strings.HasPrefix(f.key, l.namespacePrefix) {
+				continue
+			}
+
+			f.key = f.key[len(l.namespacePrefix):]
+			if ecsErrorFieldKey(f) == key {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// withFieldsInContext returns a `*rec.Logger` that fields are added as if they were added by With together with the fields in the same namespace.
+// NOTE: it rebuilds the context fields, so it is used only when rec.Error and rec.ErrorStacktrace are merged across With.
+func (l *Logger) withFieldsInContext(fields []Field) *Logger {
+	contextFields := append(make([]Field, 0, len(l.contextFieldList)+len(fields)), l.contextFieldList...)
+
+	for _, f := range fields {
+		f.key = l.namespacePrefix + f.key
+		contextFields = append(contextFields, f)
+	}
+
+	return l.withContextFields(contextFields)
+}
+
+// appendECSErrorField appends the error field as ECS error object like `"error":{"message":"...","type":"...","stack_trace":"..."}`.
+// The key of rec.ErrorStacktrace is replaced with `error`.
+// stack_trace of rec.ErrorStacktrace is `%+v` of the error if it implements fmt.Formatter, otherwise err.Error().
+func appendECSErrorField(dst []byte, f Field) []byte {
+	dst = append(dst, '"')
+	dst = appendJSONEscapedString(dst, ecsErrorFieldKey(f))
+	dst = append(dst, `":`...)
+
+	err, ok := f.interfacevalue1.(error)
+	if !ok || err == nil {
+		return append(dst, `null`...)
+	}
+
+	dst = append(dst, `{"message":"`...)
+	dst = appendJSONEscapedString(dst, err.Error())
+	dst = append(dst, `","type":"`...)
+	dst = appendJSONEscapedString(dst, fmt.Sprintf("%T", err))
+	dst = append(dst, '"')
+
+	if f.t == typeErrorStacktrace {
+		stacktrace := err.Error()
+		if formatter, ok := err.(fmt.Formatter); ok {
+			stacktrace = fmt.Sprintf("%+v", formatter)
+		}
+
+		dst = append(dst, `,"stack_trace":"`...)
+		dst = appendJSONEscapedString(dst, stacktrace)
+		dst = append(dst, '"')
+	}
+
+	return append(dst, '}')
+}
@@ -0,0 +1,101 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+type testStacktraceError struct{}
+
+func (testStacktraceError) Error() string { return "stacktrace error" }
+
+func (e testStacktraceError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		_, _ = fmt.Fprint(s, "stacktrace error\n\tmain.go:10")
+
+		return
+	}
+
+	_, _ = fmt.Fprint(s, e.Error())
+}
+
+func TestWithECSProfile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithECSProfile()))
+		l.With(String("service.name", "rec")).Error("test", Error(errForTest))
+
+		expect := regexp.MustCompile(`^{"@timestamp":"[^"]+","log.level":"error","log.origin":{"file":{"name":"[^"]+/ecs_test.go","line":[0-9]+},"function":"github.com/kunitsuinc/rec%2ego.TestWithECSProfile.func1"},"message":"test","ecs.version":"` + DefaultECSVersion + `",` +
+			`"service.name":"rec","error":{"message":"test error","type":"\*errors.errorString"}}` + defaultLineSeparator + `$`)
+		actual := buf.String()
+		FailIfNotRegexpMatchString(t, expect, actual)
+	})
+
+	t.Run("success(ErrorStacktrace)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithECSProfile(), WithUseTimestampField(false), WithUseCallerField(false)))
+		l.Error("test", ErrorStacktrace(testStacktraceError{}), ErrorStacktraceWithKey("cause", errForTest), ErrorWithKey("nil", nil))
+
+		const expect = `{"log.level":"error","message":"test","ecs.version":"` + DefaultECSVersion + `",` +
+			`"error":{"message":"stacktrace error","type":"rec.testStacktraceError","stack_trace":"stacktrace error\n\tmain.go:10"},` +
+			`"cause":{"message":"test error","type":"*errors.errorString","stack_trace":"test error"},"nil":null}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(E)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithECSProfile(), WithUseTimestampField(false), WithUseCallerField(false)))
+		l.E().Error(testStacktraceError{})
+		l.With(Error(errForTest), ErrorStacktrace(errForTest)).Info("with")
+
+		const expect = `{"log.level":"error","message":"stacktrace error","ecs.version":"` + DefaultECSVersion + `",` +
+			`"error":{"message":"stacktrace error","type":"rec.testStacktraceError","stack_trace":"stacktrace error\n\tmain.go:10"}}` + defaultLineSeparator +
+			`{"log.level":"info","message":"with","ecs.version":"` + DefaultECSVersion + `",` +
+			`"error":{"message":"test error","type":"*errors.errorString","stack_trace":"test error"}}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(With)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithECSProfile(), WithUseTimestampField(false), WithUseCallerField(false)))
+		l.With(Error(errForTest)).Info("test")
+
+		const expect = `{"log.level":"info","message":"test","ecs.version":"` + DefaultECSVersion + `","error":{"message":"test error","type":"*errors.errorString"}}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+	t.Run("success(WithThenEntry)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithECSProfile(), WithUseTimestampField(false), WithUseCallerField(false)))
+		l.With(Error(errForTest), String("service", "rec")).Info("error", ErrorStacktrace(errForTest))
+		l.With(ErrorStacktrace(errForTest)).Info("stacktrace", Error(errForTest))
+		l.With(Error(errForTest)).With(ErrorStacktrace(errForTest)).Info("with")
+		l.With(Error(errForTest)).WithNamespace("http").Info("namespace", ErrorStacktrace(errForTest))
+
+		const ecs = `"ecs.version":"` + DefaultECSVersion + `",`
+		const merged = `"error":{"message":"test error","type":"*errors.errorString","stack_trace":"test error"}`
+		const expect = `{"log.level":"info","message":"error",` + ecs + `"service":"rec",` + merged + `}` + defaultLineSeparator +
+			`{"log.level":"info","message":"stacktrace",` + ecs + merged + `}` + defaultLineSeparator +
+			`{"log.level":"info","message":"with",` + ecs + merged + `}` + defaultLineSeparator +
+			`{"log.level":"info","message":"namespace",` + ecs + `"error":{"message":"test error","type":"*errors.errorString"},"http":{` + merged + `}}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+}
@@ -0,0 +1,264 @@
+package rec
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables for NewConfigFromEnv and WithEnv.
+const DefaultEnvPrefix = "REC"
+
+// configEnvVar is the environment variable that sets the field of `*rec.Config`.
+type configEnvVar struct {
+	name string
+	set  func(value string) error
+}
+
+// nolint: funlen
+func (c *Config) envVars() []configEnvVar {
+	return []configEnvVar{
+		// timestamp
+		{"USE_TIMESTAMP", envBool(&c.UseTimestampField)},
+		{"TIMESTAMP_KEY", envString(&c.TimestampFieldKey)},
+		{"TIMESTAMP_FORMAT", envTimeFormat(&c.TimestampFieldFormat)},
+		// severity
+		{"USE_SEVERITY", envBool(&c.UseSeverityField)},
+		{"SEVERITY_KEY", envString(&c.SeverityFieldKey)},
+		{"SEVERITY_THRESHOLD", envSeverity(&c.SeverityThreshold)},
+		{"USE_UPPERCASE_SEVERITY", envBool(&c.UseUppercaseSeverity)},
+		{"DEFAULT_SEVERITY", envSeverity(&c.DefaultSeverity)},
+		// hostname
+		{"USE_HOSTNAME", envBool(&c.UseHostnameField)},
+		{"HOSTNAME_KEY", envString(&c.HostnameFieldKey)},
+		{"HOSTNAME_VALUE", envString(&c.HostnameFieldValue)},
+		// caller
+		{"USE_CALLER", envBool(&c.UseCallerField)},
+		{"CALLER_KEY", envString(&c.CallerFieldKey)},
+		{"CALLER_SKIP", envInt(&c.CallerSkip)},
+		{"USE_SHORT_CALLER", envBool(&c.UseShortCaller)},
+		{"CALLER_FORMAT", func(value string) error { c.CallerFieldFormat = CallerFormat(value); return nil }},
+		// message
+		{"USE_MESSAGE", envBool(&c.UseMessageField)},
+		{"MESSAGE_KEY", envString(&c.MessageFieldKey)},
+		// lineseparator
+		{"LINE_SEPARATOR", envString(&c.LineSeparator)},
+		// error
+		{"ERROR_FORMAT", func(value string) error { c.ErrorFieldFormat = ErrorFormat(value); return nil }},
+		// ecs
+		{"ECS_VERSION", envString(&c.ECSVersion)},
+		// sensitive
+		{"SECRET_POLICY", envSensitivePolicy(&c.SecretFieldPolicy)},
+		{"PII_POLICY", envSensitivePolicy(&c.PIIFieldPolicy)},
+		{"SENSITIVE_HASH_SALT", envString(&c.SensitiveFieldHashSalt)},
+		// trace
+		{"TRACE_ID_KEY", envString(&c.TraceIDFieldKey)},
+		{"TRACE_ID_VALUE_PREFIX", envString(&c.TraceIDFieldValuePrefix)},
+		{"SPAN_ID_KEY", envString(&c.SpanIDFieldKey)},
+		{"TRACE_SAMPLED_KEY", envString(&c.TraceSampledFieldKey)},
+		// format
+		{"FORMAT", func(value string) error { c.Format = Format(value); return nil }},
+		{"USE_COLOR", envBool(&c.UseColor)},
+		// async
+		{"ASYNC_QUEUE_SIZE", envInt(&c.AsyncQueueSize)},
+		{"ASYNC_POLICY", envAsyncPolicy(&c.AsyncPolicy)},
+		// sampling
+		{"SAMPLING_TICK", envDuration(&c.SamplingTick)},
+		{"SAMPLING_FIRST", envInt(&c.SamplingFirst)},
+		{"SAMPLING_THEREAFTER", envInt(&c.SamplingThereafter)},
+	}
+}
+
+func envString(dst *string) func(string) error {
+	return func(value string) error {
+		*dst = value
+
+		return nil
+	}
+}
+
+func envBool(dst *bool) func(string) error {
+	return func(value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("strconv.ParseBool: %w", err)
+		}
+
+		*dst = b
+
+		return nil
+	}
+}
+
+func envInt(dst *int) func(string) error {
+	return func(value string) error {
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("strconv.Atoi: %w", err)
+		}
+
+		*dst = i
+
+		return nil
+	}
+}
+
+func envDuration(dst *time.Duration) func(string) error {
+	return func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("time.ParseDuration: %w", err)
+		}
+
+		*dst = d
+
+		return nil
+	}
+}
+
+func envSeverity(dst *Severity) func(string) error {
+	return func(value string) error {
+		severity, err := ParseSeverity(value)
+		if err != nil {
+			return err
+		}
+
+		*dst = severity
+
+		return nil
+	}
+}
+
+// envTimeFormat accepts the name of the layout in time package such as `RFC3339` in addition to the Go time format and `UNIXMILLI` etc.
+func envTimeFormat(dst *string) func(string) error {
+	return func(value string) error {
+		switch strings.ToUpper(value) {
+		case "RFC3339":
+			*dst = time.RFC3339
+		case "RFC3339NANO":
+			*dst = time.RFC3339Nano
+		case TimeFormatUnixDecimal, TimeFormatUnix, TimeFormatUnixMilli, TimeFormatUnixMicro:
+			*dst = strings.ToUpper(value)
+		default:
+			*dst = value
+		}
+
+		return nil
+	}
+}
+
+func envAsyncPolicy(dst *AsyncPolicy) func(string) error {
+	return func(value string) error {
+		switch strings.ReplaceAll(strings.ToLower(value), "_", "") {
+		case "block":
+			*dst = AsyncPolicyBlock
+		case "dropnewest":
+			*dst = AsyncPolicyDropNewest
+		case "dropoldest":
+			*dst = AsyncPolicyDropOldest
+		default:
+			return fmt.Errorf("block, drop_newest or drop_oldest: %w", ErrUnknownAsyncPolicy)
+		}
+
+		return nil
+	}
+}
+
+func envSensitivePolicy(dst *SensitivePolicy) func(string) error {
+	return func(value string) error {
+		policy := SensitivePolicy(strings.ToLower(value))
+		if !policy.valid() || policy == "" {
+			return fmt.Errorf("omit, mask, hash or clear: %w", ErrUnknownSensitivePolicy)
+		}
+
+		*dst = policy
+
+		return nil
+	}
+}
+
+// envError is an error of the environment variable. It matches both rec.ErrInvalidEnvironmentVariable and the error of the value by errors.Is.
+type envError struct {
+	name  string
+	value string
+	err   error
+}
+
+func (e *envError) Error() string {
+	return fmt.Sprintf("%s=%q: %v: %v", e.name, e.value, e.err, ErrInvalidEnvironmentVariable)
+}
+
+func (e *envError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is rec.ErrInvalidEnvironmentVariable. The error of the value is matched by Unwrap.
+func (e *envError) Is(target error) bool {
+	return target == ErrInvalidEnvironmentVariable // nolint: errorlint, goerr113
+}
+
+// loadEnv sets the fields of `*rec.Config` from the environment variables that are set, even if the value is empty.
+func (c *Config) loadEnv(prefix string, lookupEnv func(string) (string, bool)) error {
+	if prefix != "" {
+		prefix += "_"
+	}
+
+	for _, envVar := range c.envVars() {
+		name := prefix + envVar.name
+
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := envVar.set(value); err != nil {
+			return &envError{name: name, value: value, err: err}
+		}
+	}
+
+	return nil
+}
+
+// NewConfigFromEnv returns *rec.Config that the default values are overwritten by the environment variables like `REC_SEVERITY_THRESHOLD=warning`.
+// If prefix is empty, the environment variables without prefix like `SEVERITY_THRESHOLD` are used.
+//
+//	PREFIX_USE_TIMESTAMP, PREFIX_TIMESTAMP_KEY, PREFIX_TIMESTAMP_FORMAT (Go time format, RFC3339, RFC3339NANO, UNIX, UNIXMILLI, etc.)
+//	PREFIX_USE_SEVERITY, PREFIX_SEVERITY_KEY, PREFIX_SEVERITY_THRESHOLD, PREFIX_USE_UPPERCASE_SEVERITY, PREFIX_DEFAULT_SEVERITY
+//	PREFIX_USE_HOSTNAME, PREFIX_HOSTNAME_KEY, PREFIX_HOSTNAME_VALUE
+//	PREFIX_USE_CALLER, PREFIX_CALLER_KEY, PREFIX_CALLER_SKIP, PREFIX_USE_SHORT_CALLER, PREFIX_CALLER_FORMAT
+//	PREFIX_USE_MESSAGE, PREFIX_MESSAGE_KEY
+//	PREFIX_LINE_SEPARATOR, PREFIX_ERROR_FORMAT, PREFIX_ECS_VERSION
+//	PREFIX_SECRET_POLICY, PREFIX_PII_POLICY (omit, mask, hash, clear), PREFIX_SENSITIVE_HASH_SALT
+//	PREFIX_TRACE_ID_KEY, PREFIX_TRACE_ID_VALUE_PREFIX, PREFIX_SPAN_ID_KEY, PREFIX_TRACE_SAMPLED_KEY
+//	PREFIX_FORMAT, PREFIX_USE_COLOR
+//	PREFIX_ASYNC_QUEUE_SIZE, PREFIX_ASYNC_POLICY (block, drop_newest, drop_oldest)
+//	PREFIX_SAMPLING_TICK, PREFIX_SAMPLING_FIRST, PREFIX_SAMPLING_THEREAFTER
+//
+// The severities are parsed by rec.ParseSeverity. AtomicSeverityThreshold, TraceExtractor, Redactor and Clock cannot be set by the environment variables.
+func NewConfigFromEnv(prefix string) (*Config, error) {
+	config := NewConfig()
+
+	if err := config.loadEnv(prefix, os.LookupEnv); err != nil {
+		return nil, fmt.Errorf("(*rec.Config).loadEnv: %w", err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("(*rec.Config).validate: %w", err)
+	}
+
+	return config, nil
+}
+
+// WithEnv returns `rec.Option` for overwriting `*rec.Config` by the environment variables. cf. NewConfigFromEnv
+//
+// The options after WithEnv overwrite the environment variables.
+func WithEnv(prefix string) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			return config.loadEnv(prefix, os.LookupEnv)
+		},
+	}
+}
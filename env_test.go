@@ -0,0 +1,143 @@
+// nolint: testpackage
+package rec
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func testLookupEnv(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+
+		return value, ok
+	}
+}
+
+func TestConfig_loadEnv(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		config := NewConfig()
+		err := config.loadEnv(DefaultEnvPrefix, testLookupEnv(map[string]string{
+			"REC_TIMESTAMP_FORMAT":   "unixmilli",
+			"REC_SEVERITY_THRESHOLD": "warn",
+			"REC_DEFAULT_SEVERITY":   "250",
+			"REC_USE_CALLER":         "false",
+			"REC_CALLER_SKIP":        "5",
+			"REC_MESSAGE_KEY":        "msg",
+			"REC_HOSTNAME_VALUE":     "",
+			"REC_FORMAT":             "logfmt",
+			"REC_ASYNC_QUEUE_SIZE":   "1024",
+			"REC_ASYNC_POLICY":       "drop_oldest",
+			"REC_SAMPLING_TICK":      "1s",
+			"REC_PII_POLICY":         "HASH",
+			"MESSAGE_KEY":            "ignored",
+		}))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotEqual(t, TimeFormatUnixMilli, config.TimestampFieldFormat)
+		FailIfNotEqual(t, WARNING, config.SeverityThreshold)
+		FailIfNotEqual(t, Severity(250), config.DefaultSeverity)
+		FailIfNotEqual(t, false, config.UseCallerField)
+		FailIfNotEqual(t, 5, config.CallerSkip)
+		FailIfNotEqual(t, "msg", config.MessageFieldKey)
+		FailIfNotEqual(t, "", config.HostnameFieldValue)
+		FailIfNotEqual(t, FormatLogfmt, config.Format)
+		FailIfNotEqual(t, 1024, config.AsyncQueueSize)
+		FailIfNotEqual(t, AsyncPolicyDropOldest, config.AsyncPolicy)
+		FailIfNotEqual(t, time.Second, config.SamplingTick)
+		FailIfNotEqual(t, SensitivePolicyHash, config.PIIFieldPolicy)
+		// NOTE: not set
+		FailIfNotEqual(t, "severity", config.SeverityFieldKey)
+	})
+
+	t.Run("success(NoPrefix,RFC3339)", func(t *testing.T) {
+		t.Parallel()
+
+		config := NewConfig()
+		FailIfNotErrorIs(t, nil, config.loadEnv("", testLookupEnv(map[string]string{"TIMESTAMP_FORMAT": "RFC3339"})))
+		FailIfNotEqual(t, time.RFC3339, config.TimestampFieldFormat)
+	})
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		expect error
+	}{
+		{"error(Bool)", map[string]string{"REC_USE_CALLER": "no"}, strconv.ErrSyntax},
+		{"error(Int)", map[string]string{"REC_CALLER_SKIP": "five"}, strconv.ErrSyntax},
+		{"error(Duration)", map[string]string{"REC_SAMPLING_TICK": "1"}, ErrInvalidEnvironmentVariable},
+		{"error(Severity)", map[string]string{"REC_SEVERITY_THRESHOLD": "unknown"}, ErrUnknownSeverity},
+		{"error(AsyncPolicy)", map[string]string{"REC_ASYNC_POLICY": "unknown"}, ErrUnknownAsyncPolicy},
+		{"error(SensitivePolicy)", map[string]string{"REC_SECRET_POLICY": "unknown"}, ErrUnknownSensitivePolicy},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewConfig().loadEnv(DefaultEnvPrefix, testLookupEnv(tt.env))
+			FailIfNotErrorIs(t, ErrInvalidEnvironmentVariable, err)
+			FailIfNotErrorIs(t, tt.expect, err)
+		})
+	}
+}
+
+// nolint: paralleltest
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Run("success()", func(t *testing.T) {
+		t.Setenv("TEST_REC_SEVERITY_THRESHOLD", "warning")
+		t.Setenv("TEST_REC_MESSAGE_KEY", "msg")
+
+		config, err := NewConfigFromEnv("TEST_REC")
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotEqual(t, WARNING, config.SeverityThreshold)
+		FailIfNotEqual(t, "msg", config.MessageFieldKey)
+	})
+
+	t.Run("error(loadEnv)", func(t *testing.T) {
+		t.Setenv("TEST_REC_USE_CALLER", "no")
+
+		_, err := NewConfigFromEnv("TEST_REC")
+		FailIfNotErrorIs(t, ErrInvalidEnvironmentVariable, err)
+	})
+
+	t.Run("error(validate)", func(t *testing.T) {
+		t.Setenv("TEST_REC_MESSAGE_KEY", "")
+
+		_, err := NewConfigFromEnv("TEST_REC")
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+	})
+
+	t.Run("error(validate,Format)", func(t *testing.T) {
+		t.Setenv("TEST_REC_FORMAT", "unknown")
+
+		_, err := NewConfigFromEnv("TEST_REC")
+		FailIfNotErrorIs(t, ErrUnknownFormat, err)
+	})
+}
+
+// nolint: paralleltest
+func TestWithEnv(t *testing.T) {
+	t.Run("success()", func(t *testing.T) {
+		t.Setenv("TEST_REC_USE_TIMESTAMP", "false")
+		t.Setenv("TEST_REC_USE_CALLER", "false")
+		t.Setenv("TEST_REC_MESSAGE_KEY", "msg")
+
+		buf := &syncBuffer{}
+		l, err := New(buf, WithEnv("TEST_REC"), WithSeverityFieldKey("level"))
+		FailIfNotErrorIs(t, nil, err)
+		l.Info("test")
+		FailIfNotEqual(t, `{"level":"INFO","msg":"test"}`+defaultLineSeparator, buf.String())
+	})
+
+	t.Run("error()", func(t *testing.T) {
+		t.Setenv("TEST_REC_SEVERITY_THRESHOLD", "unknown")
+
+		_, err := New(nil, WithEnv("TEST_REC"))
+		FailIfNotErrorIs(t, ErrInvalidEnvironmentVariable, err)
+	})
+}
@@ -12,4 +12,32 @@ var (
 	ErrSeverityLowerCaseIsEmpty = errors.New("severity lowercase is empty")
 	// ErrSeverityUpperCaseIsEmpty severity uppercase is empty.
 	ErrSeverityUpperCaseIsEmpty = errors.New("severity uppercase is empty")
+	// ErrUnknownSeverity severity is unknown.
+	ErrUnknownSeverity = errors.New("unknown severity")
+
+	// ErrUnknownFormat format is unknown.
+	ErrUnknownFormat = errors.New("unknown format")
+
+	// ErrInvalidQueueSize queue size is invalid.
+	ErrInvalidQueueSize = errors.New("invalid queue size")
+
+	// ErrInvalidSampling sampling config is invalid.
+	ErrInvalidSampling = errors.New("invalid sampling")
+
+	// ErrDiscardEntry is returned by `rec.Hook` to discard the log entry.
+	ErrDiscardEntry = errors.New("discard entry")
+
+	// ErrInvalidDurationUnit duration unit is invalid.
+	ErrInvalidDurationUnit = errors.New("invalid duration unit")
+
+	// ErrInvalidSyslogFacility syslog facility is invalid.
+	ErrInvalidSyslogFacility = errors.New("invalid syslog facility")
+
+	// ErrUnknownAsyncPolicy async policy is unknown.
+	ErrUnknownAsyncPolicy = errors.New("unknown async policy")
+	// ErrUnknownSensitivePolicy sensitive policy is unknown.
+	ErrUnknownSensitivePolicy = errors.New("unknown sensitive policy")
+
+	// ErrInvalidEnvironmentVariable environment variable is invalid.
+	ErrInvalidEnvironmentVariable = errors.New("invalid environment variable")
 )
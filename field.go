@@ -67,6 +67,11 @@ const (
 	typeErrorStacktrace
 	typeInterface
 	typeObject
+	typeGroup
+	typeObjectMarshaler
+	typeArrayMarshaler
+	typeSecret
+	typePII
 )
 
 // DefaultTimeFormat is default time format for rec.Time() and rec.TimePtr().
@@ -491,9 +496,8 @@ func appendFieldValue(dst []byte, f Field, jsonMarshalFn func(interface{}) ([]by
 	case typeObject:
 		b, err := jsonMarshalFn(f.interfacevalue1)
 		if err != nil {
-			const skip = 4
-
-			defaultLogger.AddCallerSkip(skip).Error("rec.Object: json.Marshal: "+err.Error(), Error(err))
+			// NOTE: the caller is not reported, because the field may be encoded in With, rec.Group, etc. far from the caller.
+			defaultLogger.writeWithoutCaller(ERROR, "rec.Object: json.Marshal: "+err.Error(), Error(err))
 
 			dst = append(dst, null...)
 
@@ -501,6 +505,43 @@ func appendFieldValue(dst []byte, f Field, jsonMarshalFn func(interface{}) ([]by
 		}
 
 		dst = append(dst, b...)
+	case typeGroup:
+		value, _ := f.interfacevalue1.([]Field)
+
+		dst = append(dst, '{')
+
+		for i := range value {
+			dst = append(appendJSONEscapedString(append(dst, '"'), value[i].key), '"', ':')
+			dst = append(appendFieldValue(dst, value[i], jsonMarshalFn), ',')
+		}
+
+		if dst[len(dst)-1] == ',' {
+			dst[len(dst)-1] = '}'
+		} else {
+			dst = append(dst, '}')
+		}
+	case typeObjectMarshaler:
+		value, ok := f.interfacevalue1.(ObjectMarshaler)
+		if ok && value != nil {
+			dst = appendObjectMarshaler(dst, value, jsonMarshalFn)
+
+			break
+		}
+
+		dst = append(dst, null...)
+	case typeArrayMarshaler:
+		value, ok := f.interfacevalue1.(ArrayMarshaler)
+		if ok && value != nil {
+			dst = appendArrayMarshaler(dst, value, jsonMarshalFn)
+
+			break
+		}
+
+		dst = append(dst, null...)
+	// sensitive
+	case typeSecret, typePII:
+		// NOTE: the policy of *rec.Logger is applied before encoding. If not applied, e.g. in rec.Group, the value is masked.
+		dst = append(append(append(dst, '"'), sensitiveMask...), '"')
 	// abnormal
 	case typeNone:
 		dst = append(dst, `"ERROR: TYPE NONE"`...)
@@ -987,3 +1028,58 @@ func Object(key string, object interface{}) Field {
 		interfacevalue1: object,
 	}
 }
+
+// Group returns a rec.Field for the nested JSON Object that has the passed fields as shown below:
+//
+//	rec.Group("http", rec.String("method", "GET"), rec.Int("status", 200))
+//
+// This will output a field like the following:
+//
+//	"http":{"method":"GET","status":200}
+func Group(key string, fields ...Field) Field {
+	return Field{
+		t:               typeGroup,
+		key:             key,
+		interfacevalue1: fields,
+	}
+}
+
+// Marshaler returns a rec.Field for rec.ObjectMarshaler.
+// Unlike rec.Object(), the JSON Object is appended by the value itself without reflection.
+func Marshaler(key string, value ObjectMarshaler) Field {
+	return Field{
+		t:               typeObjectMarshaler,
+		key:             key,
+		interfacevalue1: value,
+	}
+}
+
+// Array returns a rec.Field for rec.ArrayMarshaler.
+// The JSON Array is appended by the value itself without reflection.
+func Array(key string, value ArrayMarshaler) Field {
+	return Field{
+		t:               typeArrayMarshaler,
+		key:             key,
+		interfacevalue1: value,
+	}
+}
+
+// Secret returns rec.Field for the secret value such as password and API key.
+// The value is rendered by `config.SecretFieldPolicy`. Default is masked.
+func Secret(key string, value string) Field {
+	return Field{
+		t:            typeSecret,
+		key:          key,
+		stringvalue1: value,
+	}
+}
+
+// PII returns rec.Field for the personally identifiable information such as email address and phone number.
+// The value is rendered by `config.PIIFieldPolicy`. Default is masked.
+func PII(key string, value string) Field {
+	return Field{
+		t:            typePII,
+		key:          key,
+		stringvalue1: value,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"math/big"
 	"net/http"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -1731,4 +1732,80 @@ func TestObject(t *testing.T) {
 
 		FailIfNotEqual(t, expect, actual)
 	})
+
+	t.Run("error(unsupported,caller)", func(t *testing.T) {
+		backup := defaultLogger
+
+		t.Cleanup(func() { defaultLogger = backup }) // nolint: paralleltest
+
+		buf := bytes.NewBuffer(nil)
+		defaultLogger = Must(New(buf, WithUseTimestampField(false))) // nolint: paralleltest
+
+		unsupported := Object("unsupported", http.Request{Method: http.MethodGet})
+		defaultLogger.Info("group", Group("group", unsupported))
+		defaultLogger.With(unsupported).Info("with")
+
+		const message = `{"severity":"ERROR","message":"rec.Object: json.Marshal: json: unsupported type: func() (io.ReadCloser, error)","error":"json: unsupported type: func() (io.ReadCloser, error)"}` + defaultLineSeparator
+		expect := regexp.MustCompile(`^` + regexp.QuoteMeta(message) + `{"severity":"INFO","caller":"[^"]+/field_test.go:[0-9]+","message":"group","group":{"unsupported":null}}` + defaultLineSeparator +
+			regexp.QuoteMeta(message) + `{"severity":"INFO","caller":"[^"]+/field_test.go:[0-9]+","message":"with","unsupported":null}` + defaultLineSeparator + `$`)
+		actual := buf.String()
+
+		FailIfNotRegexpMatchString(t, expect, actual)
+	})
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(group)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"http":{"method":"GET","status":200,"header":{"user-agent":"rec"}}`)
+		actual := appendJSONField(bs, Group("http", String("method", "GET"), Int("status", 200), Group("header", String("user-agent", "rec"))))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(empty)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"http":{}`)
+		actual := appendJSONField(bs, Group("http"))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+}
+
+func TestSecret(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(masked)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"password":"********"`)
+		actual := appendJSONField(bs, Secret("password", "p@ss"))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+}
+
+func TestPII(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(masked)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"user":{"email":"********"}`)
+		actual := appendJSONField(bs, Group("user", PII("email", "alice@example.com")))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
 }
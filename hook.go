@@ -0,0 +1,232 @@
+package rec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Entry is a log entry passed to `rec.Hook`.
+//
+// The hook must not retain the `*rec.Entry` after returning, because it is reused.
+type Entry struct {
+	Time     time.Time
+	Severity Severity
+	Message  string
+	Fields   []Field
+	// ContextFields is the fields added by With. The keys are prefixed with the keys of WithNamespace, e.g. `http.method`.
+	// If the hook modifies them, the log entry is written with the modified fields instead of the fields added by With.
+	ContextFields []Field
+}
+
+// Hook is a function that is called before the log entry is written.
+//
+// The hook can modify the `*rec.Entry`, e.g. add or redact fields.
+// If the hook returns an error that wraps `rec.ErrDiscardEntry`, the log entry is not written.
+// If the hook returns any other error, the error is reported to the default logger and the log entry is written.
+type Hook func(entry *Entry) error
+
+// AddHook returns a `*rec.Logger` with the hook added.
+// Hooks are called in the order they were added, and are inherited by `*rec.Logger` copied by Copy, With, Renew, etc.
+func (l *Logger) AddHook(hook Hook) *Logger {
+	copied := l.Copy()
+	copied.hooks = append(copied.hooks, hook)
+
+	return copied
+}
+
+// Key returns the key of the field.
+func (f Field) Key() string {
+	return f.key
+}
+
+// Value returns the value of the field as the type passed to the constructor, e.g. int for rec.Int, time.Time for rec.Time.
+// The value of rec.Group is []rec.Field, and the value of rec.Secret and rec.PII is the string before rendering.
+// nolint: cyclop, funlen, gocyclo
+func (f Field) Value() interface{} {
+	switch f.t {
+	case typeBool:
+		return f.boolvalue1
+	case typeUint:
+		return uint(f.uint64value1)
+	case typeUint8:
+		return uint8(f.uint64value1)
+	case typeUint16:
+		return uint16(f.uint64value1)
+	case typeUint32:
+		return uint32(f.uint64value1)
+	case typeUint64:
+		return f.uint64value1
+	case typeInt:
+		return int(f.int64value1)
+	case typeInt8:
+		return int8(f.int64value1)
+	case typeInt16:
+		return int16(f.int64value1)
+	case typeInt32:
+		return int32(f.int64value1)
+	case typeInt64:
+		return f.int64value1
+	case typeFloat32:
+		return float32(f.float64value1)
+	case typeFloat64:
+		return f.float64value1
+	case typeComplex64:
+		return complex(float32(f.float64value1), float32(f.float64value2))
+	case typeComplex128:
+		return complex(f.float64value1, f.float64value2)
+	case typeTime, typeTimeFormat:
+		t := time.Unix(f.int64value1, f.int64value2)
+		if loc, ok := f.interfacevalue1.(*time.Location); ok && loc != nil {
+			t = t.In(loc)
+		}
+
+		return t
+	case typeDuration:
+		return time.Duration(f.int64value2)
+	case typeDurationFormat:
+		d, _ := time.ParseDuration(f.stringvalue1)
+
+		return d
+	case typeString, typeSecret, typePII:
+		return f.stringvalue1
+	case typeNone:
+		return nil
+	default:
+		return f.interfacevalue1
+	}
+}
+
+// runHooks calls the hooks with the log entry, and reports whether the log entry should be written.
+func (l *Logger) runHooks(entry *Entry) bool {
+	for _, hook := range l.hooks {
+		if err := hook(entry); err != nil {
+			if errors.Is(err, ErrDiscardEntry) {
+				return false
+			}
+
+			// NOTE: the error is written without hooks, otherwise a hook that always fails would recurse endlessly.
+			reporter := defaultLogger
+			if len(reporter.hooks) > 0 {
+				reporter = reporter.Copy()
+				reporter.hooks = nil
+			}
+
+			err = fmt.Errorf("(*rec.Logger).runHooks: %w", err)
+			reporter.writeAt(entry.Time, ERROR, err.Error(), Error(err))
+		}
+	}
+
+	return true
+}
+
+// fieldsModified reports whether the hooks modified the fields.
+// NOTE: the fields are compared by identity, not by deep equality, so that the unmodified fields cost almost nothing.
+func fieldsModified(modified, original []Field) bool {
+	if len(modified) != len(original) {
+		return true
+	}
+
+	for i := range modified {
+		if !sameField(modified[i], original[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameField reports whether a and b are the same field. NaN is the same as itself.
+func sameField(a, b Field) bool {
+	return a.t == b.t && a.key == b.key && a.boolvalue1 == b.boolvalue1 &&
+		a.int64value1 == b.int64value1 && a.int64value2 == b.int64value2 && a.uint64value1 == b.uint64value1 &&
+		math.Float64bits(a.float64value1) == math.Float64bits(b.float64value1) &&
+		math.Float64bits(a.float64value2) == math.Float64bits(b.float64value2) &&
+		a.stringvalue1 == b.stringvalue1 && sameValue(a.interfacevalue1, b.interfacevalue1)
+}
+
+// sameValue reports whether a and b are the same value.
+// The values that cannot be compared by == such as map, slice and func are compared by the pointer without reading the contents.
+// The other uncomparable values are reported as different, so that the log entry is rebuilt rather than the modification is lost.
+func sameValue(a, b interface{}) (same bool) {
+	typ := reflect.TypeOf(a)
+	if typ != reflect.TypeOf(b) {
+		return false
+	}
+
+	if typ == nil {
+		return true
+	}
+
+	switch typ.Kind() { // nolint: exhaustive
+	case reflect.Map, reflect.Func, reflect.Ptr, reflect.Chan, reflect.UnsafePointer:
+		return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+	case reflect.Slice:
+		va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+		return va.Pointer() == vb.Pointer() && va.Len() == vb.Len()
+	case reflect.Float32, reflect.Float64:
+		return math.Float64bits(reflect.ValueOf(a).Float()) == math.Float64bits(reflect.ValueOf(b).Float())
+	}
+
+	if !typ.Comparable() {
+		return false
+	}
+
+	// NOTE: == panics if the comparable struct has the interface field that holds the uncomparable value.
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+
+	return a == b
+}
+
+// withContextFields returns a `*rec.Logger` that the fields added by With are replaced with fields.
+// The fields are nested in the namespaces by the prefix of the keys, e.g. `http.method`.
+func (l *Logger) withContextFields(fields []Field) *Logger {
+	copied := l.Copy()
+	copied.contextFields, copied.namespaces, copied.contextFieldList = nil, 0, nil
+	copied.pendingNamespaces, copied.pendingNamespaceCount = nil, 0
+	copied.namespacePrefix, copied.namespaceKeys = "", nil
+
+	var batch []Field
+
+	depth := 0
+
+	for i := range fields {
+		// NOTE: the namespaces are never closed, so the fields are nested in the namespaces at least as deep as the previous field.
+		fieldDepth, prefix := depth, copied.namespacePrefix
+		for d, p := depth, prefix; d < len(l.namespaceKeys); {
+			d, p = d+1, p+l.namespaceKeys[d]+"."
+			if strings.HasPrefix(fields[i].key, p) {
+				fieldDepth, prefix = d, p
+			}
+		}
+
+		if fieldDepth > depth {
+			copied = copied.With(batch...)
+			batch = batch[:0]
+
+			for ; depth < fieldDepth; depth++ {
+				copied = copied.WithNamespace(l.namespaceKeys[depth])
+			}
+		}
+
+		field := fields[i]
+		field.key = strings.TrimPrefix(field.key, prefix)
+		batch = append(batch, field)
+	}
+
+	copied = copied.With(batch...)
+
+	for ; depth < len(l.namespaceKeys); depth++ {
+		copied = copied.WithNamespace(l.namespaceKeys[depth])
+	}
+
+	return copied
+}
@@ -0,0 +1,306 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_AddHook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Modify)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		l = l.AddHook(func(entry *Entry) error {
+			entry.Message = strings.ToUpper(entry.Message)
+			entry.Fields = append(entry.Fields, String("requestId", "abc"))
+
+			return nil
+		})
+		l = l.AddHook(func(entry *Entry) error {
+			for i := range entry.Fields {
+				if entry.Fields[i].Key() == "password" {
+					entry.Fields[i] = String("password", "***")
+				}
+			}
+
+			return nil
+		})
+
+		fields := []Field{String("password", "secret")}
+		l.Info("test", fields...)
+
+		const expect = `{"severity":"INFO","message":"TEST","password":"***","requestId":"abc"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+		FailIfNotEqual(t, "secret", fields[0].stringvalue1)
+	})
+
+	t.Run("success(Discard)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+
+		counts := make(map[Severity]int)
+		l = l.AddHook(func(entry *Entry) error {
+			counts[entry.Severity]++
+
+			if entry.Severity < WARNING {
+				return fmt.Errorf("severity=%d: %w", entry.Severity, ErrDiscardEntry)
+			}
+
+			return nil
+		})
+
+		l.Info("test")
+		l.Warning("test")
+		l.Warning("test")
+
+		const expect = `{"severity":"WARNING","message":"test"}` + defaultLineSeparator + `{"severity":"WARNING","message":"test"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+		FailIfNotDeepEqual(t, map[Severity]int{INFO: 1, WARNING: 2}, counts)
+	})
+
+	t.Run("success(Inherit)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		l = l.AddHook(func(entry *Entry) error {
+			entry.Fields = append(entry.Fields, Bool("hooked", true))
+
+			return nil
+		})
+
+		l.Copy().Info("copy")
+		l.With(String("with", "value")).Info("with")
+		Must(l.Renew(WithUseSeverityField(false))).Info("renew")
+		l.RenewWriter(buf).Info("renewWriter")
+
+		child := l.AddHook(func(entry *Entry) error { return ErrDiscardEntry })
+		child.Info("child")
+		l.Info("parent")
+
+		const expect = `{"severity":"INFO","message":"copy","hooked":true}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"with","with":"value","hooked":true}` + defaultLineSeparator +
+			`{"message":"renew","hooked":true}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"renewWriter","hooked":true}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"parent","hooked":true}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(Copy)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 100; i++ {
+				l.Info("parent")
+			}
+		}()
+
+		hooked := l.AddHook(func(entry *Entry) error { return ErrDiscardEntry })
+		hooked.Info("child")
+		<-done
+
+		FailIfNotEqual(t, 100, strings.Count(buf.String(), `"message":"parent"`))
+		FailIfNotEqual(t, 0, strings.Count(buf.String(), `"message":"child"`))
+	})
+
+	t.Run("success(SeverityThreshold)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithSeverityThreshold(WARNING)))
+		l = l.AddHook(func(entry *Entry) error {
+			if entry.Message == "downgrade" {
+				entry.Severity = INFO
+			}
+
+			return nil
+		})
+
+		l.Warning("downgrade")
+		l.Warning("test")
+
+		const expect = `{"severity":"WARNING","message":"test"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(ContextFields)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		l = l.AddHook(func(entry *Entry) error {
+			for i := range entry.ContextFields {
+				if entry.ContextFields[i].Key() == "http.password" {
+					entry.ContextFields[i] = String("http.password", "***")
+				}
+			}
+
+			return nil
+		})
+
+		child := l.With(String("service", "rec")).WithNamespace("http").With(String("password", "secret")).WithNamespace("header")
+		child.Info("test", String("userAgent", "rec"))
+		child.With(String("method", "GET")).Info("test")
+		l.With(String("service", "rec")).Info("test")
+
+		const expect = `{"severity":"INFO","message":"test","service":"rec","http":{"password":"***","header":{"userAgent":"rec"}}}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"test","service":"rec","http":{"password":"***","header":{"method":"GET"}}}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"test","service":"rec"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(ContextFields,Logfmt)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatLogfmt), WithUseTimestampField(false), WithUseCallerField(false)))
+		l = l.AddHook(func(entry *Entry) error {
+			entry.ContextFields = entry.ContextFields[:0]
+
+			return nil
+		})
+
+		l.With(String("password", "secret")).Info("test", String("user", "alice"))
+
+		const expect = `severity=INFO message=test user=alice` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+}
+
+func TestField_Value(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 2, 3, 4, 5, 6, time.FixedZone("JST", 9*60*60))
+	err := fmt.Errorf("value")
+	group := []Field{String("key", "value")}
+
+	tests := []struct {
+		field Field
+		value interface{}
+	}{
+		{Bool("bool", true), true},
+		{Uint("uint", 1), uint(1)},
+		{Uint8("uint8", 1), uint8(1)},
+		{Uint64("uint64", 1), uint64(1)},
+		{Int("int", -1), -1},
+		{Int32("int32", -1), int32(-1)},
+		{Int64("int64", -1), int64(-1)},
+		{Float32("float32", 1.5), float32(1.5)},
+		{Float64("float64", 1.5), 1.5},
+		{Complex64("complex64", 1+2i), complex64(1 + 2i)},
+		{Complex128("complex128", 1+2i), 1 + 2i},
+		{Time("time", now), now},
+		{TimeFormat("timeFormat", time.RFC3339, now), now},
+		{Duration("duration", time.Second, 3*time.Second), 3 * time.Second},
+		{DurationFormat("durationFormat", 3*time.Second), 3 * time.Second},
+		{String("string", "value"), "value"},
+		{Secret("secret", "value"), "value"},
+		{Error(err), err},
+		{Group("group", group...), group},
+		{Field{}, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.field.Key(), func(t *testing.T) {
+			t.Parallel()
+
+			FailIfNotDeepEqual(t, tt.value, tt.field.Value())
+		})
+	}
+}
+
+func Test_fieldsModified(t *testing.T) {
+	t.Parallel()
+
+	object := map[string]interface{}{"key": "value"}
+	fn := func() {}
+	original := []Field{Float64("nan", math.NaN()), Object("object", object), Interface("func", fn), Group("group", String("key", "value")), Object("struct", struct{ V string }{"value"})}
+
+	tests := []struct {
+		name   string
+		i      int
+		field  Field
+		expect bool
+	}{
+		{"success(NaN)", 0, Float64("nan", math.NaN()), false},
+		{"success(Value)", 0, Float64("nan", 1), true},
+		{"success(Key)", 1, Object("renamed", object), true},
+		{"success(Object)", 1, Object("object", map[string]interface{}{"key": "value"}), true},
+		{"success(Group)", 3, Group("group", String("key", "value")), true},
+		{"success(Struct)", 4, Object("struct", struct{ V string }{"value"}), false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			modified := append([]Field(nil), original...)
+			modified[tt.i] = tt.field
+			FailIfNotEqual(t, tt.expect, fieldsModified(modified, original))
+		})
+	}
+
+	FailIfNotEqual(t, false, fieldsModified(append([]Field(nil), original...), original))
+	FailIfNotEqual(t, true, fieldsModified(original[:1], original))
+}
+
+func Test_clearFields(t *testing.T) {
+	t.Parallel()
+
+	fields := []Field{String("key", "value"), Object("object", struct{}{})}
+	cleared := clearFields(fields)
+
+	FailIfNotEqual(t, 0, len(cleared))
+	FailIfNotEqual(t, 2, cap(cleared))
+	FailIfNotDeepEqual(t, []Field{{}, {}}, fields)
+}
+
+// nolint: paralleltest
+func TestLogger_AddHook_Error(t *testing.T) {
+	defaultBuf := bytes.NewBuffer(nil)
+	l := Must(New(defaultBuf, WithUseTimestampField(false), WithUseCallerField(false)))
+	// NOTE: the error is reported to the default logger without hooks, so the hook of the default logger does not recurse.
+	l = l.AddHook(func(entry *Entry) error { return errForTest })
+	rollback := ReplaceDefaultLogger(l)
+	defer rollback()
+
+	l.Info("test")
+
+	const expect = `{"severity":"ERROR","message":"(*rec.Logger).runHooks: test error","error":"(*rec.Logger).runHooks: test error"}` + defaultLineSeparator +
+		`{"severity":"INFO","message":"test"}` + defaultLineSeparator
+	actual := defaultBuf.String()
+	FailIfNotEqual(t, expect, actual)
+
+	// NOTE: the error is written through the severity threshold of the default logger.
+	defaultBuf.Reset()
+	rollbackThreshold := ReplaceDefaultLogger(Must(l.Renew(WithSeverityThreshold(CRITICAL))))
+	defer rollbackThreshold()
+
+	l.Info("test")
+
+	FailIfNotEqual(t, `{"severity":"INFO","message":"test"}`+defaultLineSeparator, defaultBuf.String())
+}
@@ -1,14 +1,22 @@
 package rec
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"runtime"
 	"sync"
 	"time"
 )
 
-var defaultLogger = Must(NewWithConfig(os.Stderr, NewConfig())) // nolint: gochecknoglobals
+var defaultLogger *Logger // nolint: gochecknoglobals
+
+// nolint: gochecknoinits
+func init() {
+	// NOTE: defaultLogger is initialized in init() because *rec.AsyncWriter reports write errors to defaultLogger.
+	defaultLogger = Must(NewWithConfig(os.Stderr, NewConfig()))
+}
 
 // Logger is the main struct of rec.
 type Logger struct {
@@ -19,6 +27,24 @@ type Logger struct {
 	config *Config
 
 	contextFields []byte
+	// namespaces is the number of JSON Objects opened in contextFields by WithNamespace.
+	namespaces int
+	// pendingNamespaces is the JSON Objects opened by WithNamespace that have no fields yet, e.g. `"a":{"b":{`.
+	// They are appended to contextFields or the log entry when the first field is added, so that empty namespaces are omitted.
+	pendingNamespaces []byte
+	// pendingNamespaceCount is the number of JSON Objects in pendingNamespaces.
+	pendingNamespaceCount int
+	// contextFieldList is the fields added by With before rendering rec.Secret and rec.PII. The keys are prefixed with namespacePrefix.
+	contextFieldList []Field
+	// namespacePrefix is the keys joined by WithNamespace, e.g. `http.`.
+	namespacePrefix string
+	// namespaceKeys is the keys of WithNamespace, in order to rebuild the context fields modified by the hooks.
+	namespaceKeys []string
+
+	// sampler is shared by the copied `*rec.Logger`. If nil, log entries are not sampled.
+	sampler *sampler
+
+	hooks []Hook
 
 	writer io.Writer
 }
@@ -45,10 +71,28 @@ func NewWithConfig(writer io.Writer, config *Config) (*Logger, error) {
 		customSeverities: defaultSeverities(),
 		config:           config,
 		contextFields:    make([]byte, 0),
-		writer:           writer,
+		sampler:          samplerIfNeeded(nil, config),
+		writer:           asyncWriterIfNeeded(writer, config),
 	}, nil
 }
 
+// asyncWriterIfNeeded wraps writer with `*rec.AsyncWriter` if config.AsyncQueueSize is set.
+// `*rec.MultiSinkWriter` is not wrapped because the severity is required to write to it. Wrap each sink instead.
+func asyncWriterIfNeeded(writer io.Writer, config *Config) io.Writer {
+	switch writer.(type) {
+	case *AsyncWriter, *MultiSinkWriter, *SyslogWriter:
+		return writer
+	}
+
+	if config.AsyncQueueSize <= 0 {
+		return writer
+	}
+
+	asyncWriter, _ := NewAsyncWriter(writer, config.AsyncQueueSize, config.AsyncPolicy) // NOTE: never returns error because config.AsyncQueueSize > 0
+
+	return asyncWriter
+}
+
 // New creates a *rec.Logger from rec.Option.
 func New(writer io.Writer, options ...Option) (*Logger, error) {
 	config := NewConfig()
@@ -108,6 +152,30 @@ func (l *Logger) Copy() *Logger {
 		copiedLogger.contextFields = append(copiedLogger.contextFields, l.contextFields...)
 	}
 
+	copiedLogger.namespaces = l.namespaces
+
+	if len(l.pendingNamespaces) > 0 {
+		copiedLogger.pendingNamespaces = append(copiedLogger.pendingNamespaces, l.pendingNamespaces...)
+	}
+
+	copiedLogger.pendingNamespaceCount = l.pendingNamespaceCount
+
+	if len(l.contextFieldList) > 0 {
+		copiedLogger.contextFieldList = append(copiedLogger.contextFieldList, l.contextFieldList...)
+	}
+
+	copiedLogger.namespacePrefix = l.namespacePrefix
+
+	if len(l.namespaceKeys) > 0 {
+		copiedLogger.namespaceKeys = append(copiedLogger.namespaceKeys, l.namespaceKeys...)
+	}
+
+	copiedLogger.sampler = l.sampler
+
+	if len(l.hooks) > 0 {
+		copiedLogger.hooks = append(copiedLogger.hooks, l.hooks...)
+	}
+
 	return copiedLogger
 }
 
@@ -131,15 +199,72 @@ func (l *Logger) AddCallerSkip(callerSkip int) *Logger {
 //	$ go run main.go
 //	{"timestamp":"...",...,"message":"rec","field":"added"}
 func (l *Logger) With(fields ...Field) *Logger {
+	// NOTE: rec.Error and rec.ErrorStacktrace of the same key added by the different calls of With are merged by encoding them together.
+	if l.config.ErrorFieldFormat == ErrorFormatECS && l.mergesContextECSErrorField(fields) {
+		return l.withFieldsInContext(fields)
+	}
+
 	copied := l.Copy()
 
 	for i := range fields {
-		copied.contextFields = append(appendJSONField(copied.contextFields, fields[i]), ',')
+		// NOTE: the field is kept before rendering, so that rec.Secret and rec.PII follow the policy set by Renew.
+		raw := fields[i] // copy
+		raw.key = copied.namespacePrefix + raw.key
+		copied.contextFieldList = append(copied.contextFieldList, raw)
+
+		field, ok := copied.sensitiveField(fields[i])
+		if !ok {
+			continue
+		}
+
+		// NOTE: rec.Error merged into rec.ErrorStacktrace is omitted only in JSON.
+		ecs := copied.config.ErrorFieldFormat == ErrorFormatECS && isECSErrorField(field)
+		if !ecs || !isMergedECSErrorField(fields, i) {
+			// open the namespaces when the first field is added.
+			if copied.pendingNamespaceCount > 0 {
+				copied.contextFields = append(copied.contextFields, copied.pendingNamespaces...)
+				copied.namespaces += copied.pendingNamespaceCount
+				copied.pendingNamespaces, copied.pendingNamespaceCount = nil, 0
+			}
+
+			start := len(copied.contextFields)
+
+			if ecs {
+				copied.contextFields = appendECSErrorField(copied.contextFields, field)
+			} else {
+				copied.contextFields = appendJSONField(copied.contextFields, field)
+			}
+
+			copied.contextFields = append(copied.config.Redactor.redactJSONField(copied.contextFields, start), ',')
+		}
 	}
 
 	return copied
 }
 
+// WithNamespace returns a `rec.Logger` that nests the fields added after this under the key.
+// If no fields are added under the key, the key is omitted.
+//
+// For example, for the following `rec.Logger`:
+//
+//	l := rec.Must(rec.New(os.Stderr)).With(rec.String("field", "added")).WithNamespace("http")
+//	l.Info("rec", rec.String("method", "GET"), rec.Int("status", 200))
+//
+// This will output a log like the following:
+//
+//	$ go run main.go
+//	{"timestamp":"...",...,"message":"rec","field":"added","http":{"method":"GET","status":200}}
+func (l *Logger) WithNamespace(key string) *Logger {
+	copied := l.Copy()
+
+	copied.pendingNamespaces = append(appendJSONEscapedString(append(copied.pendingNamespaces, '"'), key), `":{`...)
+	copied.pendingNamespaceCount++
+	copied.namespacePrefix += key + "."
+	copied.namespaceKeys = append(copied.namespaceKeys, key)
+
+	return copied
+}
+
 // Renew copies the `*rec.Logger`, applies `rec.Option` to it, and returns it.
 func (l *Logger) Renew(options ...Option) (*Logger, error) {
 	copied := l.Copy()
@@ -150,107 +275,198 @@ func (l *Logger) Renew(options ...Option) (*Logger, error) {
 		}
 	}
 
+	// NOTE: the fields added by With are encoded again, so that the options like WithRedactor apply to them.
+	if len(copied.contextFieldList) > 0 {
+		copied = copied.withContextFields(copied.contextFieldList)
+	}
+
+	copied.sampler = samplerIfNeeded(copied.sampler, copied.config)
+	copied.writer = asyncWriterIfNeeded(copied.writer, copied.config)
+
 	return copied, nil
 }
 
 // RenewWriter copies the `*rec.Logger`, set a new io.Writer for it, and returns it.
+// If the `*rec.Logger` writes asynchronously, the new io.Writer is also wrapped with `*rec.AsyncWriter`.
 func (l *Logger) RenewWriter(writer io.Writer) *Logger {
 	copied := l.Copy()
-	copied.writer = writer
+	copied.writer = asyncWriterIfNeeded(writer, copied.config)
 
 	return copied
 }
 
-// nolint: cyclop, funlen
-func (l *Logger) write(now time.Time, severity Severity, message string, fields ...Field) {
-	if severity < l.config.SeverityThreshold {
+func (l *Logger) write(severity Severity, message string, fields ...Field) {
+	l.output(time.Time{}, true, severity, message, fields)
+}
+
+// writeAt is write at the given time, e.g. the time of the log entry that failed to be written.
+func (l *Logger) writeAt(now time.Time, severity Severity, message string, fields ...Field) {
+	l.output(now, true, severity, message, fields)
+}
+
+// writeWithoutCaller writes the log entry without the caller field, e.g. the errors that rec reports while encoding, the access log of the HTTP middleware.
+func (l *Logger) writeWithoutCaller(severity Severity, message string, fields ...Field) {
+	l.output(time.Time{}, false, severity, message, fields)
+}
+
+// output writes the log entry through the severity threshold and the sampler. If now is zero, the clock is read.
+// NOTE: output must be called directly by write, writeAt or writeWithoutCaller, because the caller is counted from them.
+func (l *Logger) output(now time.Time, withCaller bool, severity Severity, message string, fields []Field) {
+	if severity < l.severityThreshold() {
 		return
 	}
 
-	b := bufferPool.Get().(*buffer) // nolint: forcetypeassert
-	defer bufferPool.Put(b)
+	// NOTE: read the clock after the severity threshold, so that the filtered log entries do not advance `rec.FakeClock`.
+	if now.IsZero() {
+		now = l.now()
+	}
 
-	// reset
-	b.Buffer = b.Buffer[:0]
-
-	// {
-	b.Buffer = append(b.Buffer, '{')
-
-	// {"timestamp":"...",
-	if l.config.UseTimestampField {
-		b.Buffer = append(b.Buffer, '"')
-		b.Buffer = appendJSONEscapedString(b.Buffer, l.config.TimestampFieldKey)
-		b.Buffer = append(b.Buffer, `":`...)
-		b.Buffer = appendTimeFieldValue(b.Buffer, now, l.config.TimestampFieldFormat)
-		b.Buffer = append(b.Buffer, ',')
+	// NOTE: sample before caller and encoding, so sampled out log entries cost almost nothing.
+	if l.sampler != nil && !l.sampler.allow(now, severity, message) {
+		return
 	}
 
-	// {"timestamp":"...","severity":"...",
-	if l.config.UseSeverityField {
-		b.Buffer = append(b.Buffer, '"')
-		b.Buffer = appendJSONEscapedString(b.Buffer, l.config.SeverityFieldKey)
-		b.Buffer = append(b.Buffer, `":"`...)
+	var frame runtime.Frame
+	if withCaller && l.config.UseCallerField {
+		frame = callerFrame(l.config.CallerSkip + 1)
+	}
+
+	l.writeEntry(now, severity, frame, message, fields)
+}
 
-		if l.config.UseUppercaseSeverity {
-			b.Buffer = appendJSONEscapedString(b.Buffer, l.uppercase(severity))
-		} else {
-			b.Buffer = appendJSONEscapedString(b.Buffer, l.lowercase(severity))
+func (l *Logger) writeEntry(now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) {
+	if len(l.hooks) > 0 {
+		entry := entryPool.Get().(*Entry) // nolint: forcetypeassert
+		defer func() {
+			// NOTE: release the references to the values of the fields, because the slices are reused.
+			*entry = Entry{Fields: clearFields(entry.Fields), ContextFields: clearFields(entry.ContextFields)}
+			entryPool.Put(entry)
+		}()
+
+		// NOTE: fields are copied so that the hooks do not modify the caller's slice.
+		entry.Time, entry.Severity, entry.Message = now, severity, message
+		entry.Fields = append(entry.Fields[:0], fields...)
+		entry.ContextFields = append(entry.ContextFields[:0], l.contextFieldList...)
+
+		if !l.runHooks(entry) {
+			return
 		}
 
-		b.Buffer = append(b.Buffer, `",`...)
-	}
+		now, severity, message, fields = entry.Time, entry.Severity, entry.Message, entry.Fields
 
-	// {"timestamp":"...","severity":"...","hostname":"...",
-	if l.config.UseHostnameField {
-		b.Buffer = append(b.Buffer, '"')
-		b.Buffer = appendJSONEscapedString(b.Buffer, l.config.HostnameFieldKey)
-		b.Buffer = append(b.Buffer, `":"`...)
-		b.Buffer = appendJSONEscapedString(b.Buffer, l.config.HostnameFieldValue)
-		b.Buffer = append(b.Buffer, `",`...)
-	}
+		// NOTE: the hooks may lower the severity.
+		if severity < l.severityThreshold() {
+			return
+		}
 
-	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...",
-	if l.config.UseCallerField {
-		b.Buffer = append(b.Buffer, '"')
-		b.Buffer = appendJSONEscapedString(b.Buffer, l.config.CallerFieldKey)
-		b.Buffer = append(b.Buffer, `":"`...)
-		b.Buffer = appendCaller(b.Buffer, l.config.CallerSkip, l.config.UseShortCaller)
-		b.Buffer = append(b.Buffer, `",`...)
+		if fieldsModified(entry.ContextFields, l.contextFieldList) {
+			l.withContextFields(entry.ContextFields).encodeAndWrite(now, severity, frame, message, fields)
+
+			return
+		}
 	}
 
-	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...",
-	if l.config.UseMessageField {
-		b.Buffer = append(b.Buffer, '"')
-		b.Buffer = appendJSONEscapedString(b.Buffer, l.config.MessageFieldKey)
-		b.Buffer = append(b.Buffer, `":"`...)
-		b.Buffer = appendJSONEscapedString(b.Buffer, message)
-		b.Buffer = append(b.Buffer, `",`...)
+	l.encodeAndWrite(now, severity, frame, message, fields)
+}
+
+// clearFields zeroes the fields and returns the empty slice to be reused.
+func clearFields(fields []Field) []Field {
+	for i := range fields {
+		fields[i] = Field{}
 	}
 
-	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...",
-	if len(l.contextFields) > 0 {
-		b.Buffer = append(b.Buffer, l.contextFields...)
+	return fields[:0]
+}
+
+func (l *Logger) encodeAndWrite(now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) {
+	// encode once per format and write to the sinks that accept the severity.
+	if multiSinkWriter, ok := l.writer.(*MultiSinkWriter); ok {
+		l.writeSinks(multiSinkWriter, now, severity, frame, message, fields)
+
+		return
 	}
 
-	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...","fields":"...",
-	for i := range fields {
-		b.Buffer = append(appendJSONField(b.Buffer, fields[i]), ',')
+	// map the severity to the syslog severity.
+	if syslogWriter, ok := l.writer.(*SyslogWriter); ok {
+		l.writeSyslog(syslogWriter, now, severity, frame, message, fields)
+
+		return
 	}
 
-	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...","fields":"..."}
-	if b.Buffer[len(b.Buffer)-1] == ',' {
-		b.Buffer[len(b.Buffer)-1] = '}'
-	} else {
-		b.Buffer = append(b.Buffer, '}')
+	b := bufferPool.Get().(*buffer) // nolint: forcetypeassert
+
+	// reset
+	b.Buffer = l.appendEntry(b.Buffer[:0], l.config.Format, now, severity, frame, message, fields)
+
+	// hand b to the background goroutine without copying. b is put back to bufferPool by *AsyncWriter.
+	if asyncWriter, ok := l.writer.(*AsyncWriter); ok {
+		asyncWriter.enqueue(b)
+
+		return
 	}
 
-	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...","fields":"..."}\n
-	b.Buffer = append(b.Buffer, l.config.LineSeparator...)
+	defer bufferPool.Put(b)
 
 	if _, err := l.writer.Write(b.Buffer); err != nil {
 		err = fmt.Errorf("(*rec.Logger).write: writer=%#v: Write: %w", l.writer, err)
-		defaultLogger.write(now, ERROR, err.Error(), Error(err))
+		defaultLogger.writeAt(now, ERROR, err.Error(), Error(err))
+	}
+}
+
+// appendEntry appends the log entry encoded in the format, and the line separator.
+func (l *Logger) appendEntry(dst []byte, format Format, now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) []byte {
+	switch format {
+	case FormatConsole:
+		dst = l.appendConsoleEntry(dst, now, severity, frame, message, fields)
+	case FormatLogfmt:
+		dst = l.appendLogfmtEntry(dst, now, severity, frame, message, fields)
+	case FormatJSON:
+		fallthrough
+	default:
+		dst = l.appendJSONEntry(dst, now, severity, frame, message, fields)
+	}
+
+	// \n
+	return append(dst, l.config.LineSeparator...)
+}
+
+// Flush writes the buffered log entries to the underlying io.Writer.
+// If the io.Writer is `*rec.AsyncWriter`, Flush waits until the queue is empty or ctx is done.
+// If the io.Writer has `Flush() error` method like `*bufio.Writer`, Flush calls it.
+func (l *Logger) Flush(ctx context.Context) error {
+	switch w := l.writer.(type) {
+	case interface{ Flush(context.Context) error }:
+		if err := w.Flush(ctx); err != nil {
+			return fmt.Errorf("writer=%#v: Flush: %w", l.writer, err)
+		}
+	case interface{ Flush() error }:
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("writer=%#v: Flush: %w", l.writer, err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the io.Writer of the `*rec.Logger` if it implements io.Closer.
+// Note that `*rec.Logger` copied by Copy, With, Renew, etc. shares the io.Writer.
+func (l *Logger) Close() error {
+	if w, ok := l.writer.(io.Closer); ok {
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("writer=%#v: Close: %w", l.writer, err)
+		}
+	}
+
+	return nil
+}
+
+// Dropped returns the number of log entries dropped by the io.Writer of the `*rec.Logger`.
+func (l *Logger) Dropped() uint64 {
+	if w, ok := l.writer.(interface{ Dropped() uint64 }); ok {
+		return w.Dropped()
 	}
+
+	return 0
 }
 
 func (l *Logger) Write(b []byte) (int, error) {
@@ -258,7 +474,7 @@ func (l *Logger) Write(b []byte) (int, error) {
 		b = b[:len(b)-1]
 	}
 
-	l.write(time.Now(), l.config.DefaultSeverity, string(b))
+	l.write(l.config.DefaultSeverity, string(b))
 
 	return len(b), nil
 }
@@ -0,0 +1,165 @@
+package rec
+
+import (
+	"encoding/json"
+	"runtime"
+	"time"
+)
+
+const (
+	consoleSeverityWidth = len(uppercaseEmergency)
+	consoleColorReset    = "\033[0m"
+)
+
+// consoleSeverityColor returns ANSI escape sequence for the severity.
+func consoleSeverityColor(severity Severity) string {
+	switch {
+	case severity < DEBUG:
+		return ""
+	case severity < INFO:
+		return "\033[34m" // blue
+	case severity < NOTICE:
+		return "\033[32m" // green
+	case severity < WARNING:
+		return "\033[36m" // cyan
+	case severity < ERROR:
+		return "\033[33m" // yellow
+	case severity < CRITICAL:
+		return "\033[31m" // red
+	default:
+		return "\033[1;31m" // bold red
+	}
+}
+
+// appendConsoleEntry appends a human-readable log entry like the following:
+//
+//	2009-11-10T23:00:00Z INFO      main.go:10 message key="value"
+//
+// nolint: cyclop
+func (l *Logger) appendConsoleEntry(dst []byte, now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) []byte {
+	start := len(dst)
+
+	// 2009-11-10T23:00:00Z
+	if l.config.UseTimestampField && !now.IsZero() {
+		dst = appendConsoleTime(dst, now, l.config.TimestampFieldFormat)
+	}
+
+	// 2009-11-10T23:00:00Z INFO
+	if l.config.UseSeverityField {
+		dst = appendConsoleSeparator(dst, start)
+		dst = l.appendConsoleSeverity(dst, severity)
+	}
+
+	// 2009-11-10T23:00:00Z INFO      hostname
+	if l.config.UseHostnameField {
+		dst = appendConsoleSeparator(dst, start)
+		dst = appendConsoleString(dst, l.config.HostnameFieldValue)
+	}
+
+	// 2009-11-10T23:00:00Z INFO      hostname main.go:10
+	if l.config.UseCallerField && !isZeroFrame(frame) {
+		dst = appendConsoleSeparator(dst, start)
+		dst = appendCallerFromFrame(dst, frame, l.config.UseShortCaller)
+	}
+
+	// 2009-11-10T23:00:00Z INFO      hostname main.go:10 message
+	if l.config.UseMessageField {
+		dst = appendConsoleSeparator(dst, start)
+		dst = appendConsoleString(dst, l.config.Redactor.maskString(message))
+	}
+
+	// 2009-11-10T23:00:00Z INFO      hostname main.go:10 message context="..."
+	for i := range l.contextFieldList {
+		field, ok := l.sensitiveField(l.contextFieldList[i])
+		if !ok {
+			continue
+		}
+
+		dst = appendConsoleSeparator(dst, start)
+		dst = appendConsoleField(dst, "", field, l.config.Redactor)
+	}
+
+	// 2009-11-10T23:00:00Z INFO      hostname main.go:10 message context="..." field="..."
+	for i := range fields {
+		field, ok := l.sensitiveField(fields[i])
+		if !ok {
+			continue
+		}
+
+		dst = appendConsoleSeparator(dst, start)
+		dst = appendConsoleField(dst, l.namespacePrefix, field, l.config.Redactor)
+	}
+
+	return dst
+}
+
+func appendConsoleSeparator(dst []byte, start int) []byte {
+	if len(dst) > start {
+		dst = append(dst, ' ')
+	}
+
+	return dst
+}
+
+func (l *Logger) appendConsoleSeverity(dst []byte, severity Severity) []byte {
+	var s string
+	if l.config.UseUppercaseSeverity {
+		s = l.uppercase(severity)
+	} else {
+		s = l.lowercase(severity)
+	}
+
+	color := ""
+	if l.config.UseColor {
+		color = consoleSeverityColor(severity)
+	}
+
+	if color != "" {
+		dst = append(dst, color...)
+	}
+
+	dst = append(dst, s...)
+
+	if color != "" {
+		dst = append(dst, consoleColorReset...)
+	}
+
+	for i := len(s); i < consoleSeverityWidth; i++ {
+		dst = append(dst, ' ')
+	}
+
+	return dst
+}
+
+// appendConsoleString appends s as it is, or quoted like JSON string if s has the control characters,
+// so that the line breaks and the escape sequences in s cannot forge log entries or the terminal output.
+func appendConsoleString(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] == 0x7f {
+			return append(appendJSONEscapedString(append(dst, '"'), s), '"')
+		}
+	}
+
+	return append(dst, s...)
+}
+
+func appendConsoleTime(dst []byte, t time.Time, format string) []byte {
+	switch format {
+	case "", TimeFormatUnixDecimal, TimeFormatUnix, TimeFormatUnixMilli, TimeFormatUnixMicro:
+		return appendTimeFieldValue(dst, t, format)
+	default:
+		return t.AppendFormat(dst, format)
+	}
+}
+
+// appendConsoleField appends `key=value`. The value is the same as JSON.
+func appendConsoleField(dst []byte, prefix string, f Field, redactor *Redactor) []byte {
+	dst = append(dst, prefix...)
+	dst = append(dst, f.key...)
+	dst = append(dst, '=')
+
+	start := len(dst)
+	dst = appendFieldValue(dst, f, json.Marshal)
+
+	return redactor.redactValue(dst, start, f.key)
+}
@@ -0,0 +1,116 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLogger_appendConsoleEntry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Fields)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatConsole), WithUseColor(false), WithUseCallerField(false)))
+
+		l.With(String("context", "value")).WithNamespace("http").With(String("method", "GET")).writeAt(testTimestampValue, WARNING, "test",
+			Int("status", 200),
+			Strings("strings", []string{"a", "b"}),
+			Group("group", Bool("bool", true)),
+			Array("tags", testTags{"a"}),
+			Errors([]error{errForTest}),
+		)
+
+		const expect = `2021-01-01T10:23:45.6789+09:00 WARNING   test context="value" http.method="GET" http.status=200 http.strings=["a","b"] http.group={"bool":true} http.tags=["a"] http.errors=["test error"]` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(Color)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatConsole), WithUseColor(true), WithTimestampFieldFormat(TimeFormatUnix), WithUseUppercaseSeverity(false), WithUseHostnameField(true), WithHostnameFieldValue("localhost")))
+
+		l.writeAt(time.Unix(1, 0), ERROR, "test")
+
+		expect := regexp.MustCompile(`^1 ` + "\033\\[31m" + `error` + "\033\\[0m" + `     localhost [^ ]+:[0-9]+ test` + defaultLineSeparator + `$`)
+		actual := buf.String()
+		FailIfNotRegexpMatchString(t, expect, actual)
+	})
+
+	t.Run("success(NoColorByDefault)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatConsole), WithUseTimestampField(false), WithUseCallerField(false)))
+
+		l.Error("test")
+
+		const expect = `ERROR     test` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(ControlCharacters)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatConsole), WithUseTimestampField(false), WithUseCallerField(false), WithUseHostnameField(true), WithHostnameFieldValue("host\n")))
+
+		l.Info("test\nERROR     forged \x1b[31m\x7f", String("key", "value"))
+		l.Info(`"quoted" test`)
+
+		const expect = `INFO      "host\n" "test\nERROR     forged \u001b[31m` + "\x7f" + `" key="value"` + defaultLineSeparator +
+			`INFO      "host\n" "quoted" test` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(NoField)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatConsole)))
+		l.config = &Config{Format: FormatConsole}
+
+		l.writeAt(testTimestampValue, DEFAULT, "test", String("key", "value"))
+
+		const expect = `key="value"`
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+}
+
+func Test_consoleSeverityColor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		severity Severity
+		expect   string
+	}{
+		{"DEFAULT", DEFAULT, ""},
+		{"DEBUG", DEBUG, "\033[34m"},
+		{"INFO", INFO, "\033[32m"},
+		{"NOTICE", NOTICE, "\033[36m"},
+		{"WARNING", WARNING, "\033[33m"},
+		{"ERROR", ERROR, "\033[31m"},
+		{"CRITICAL", CRITICAL, "\033[1;31m"},
+		{"custom", 150, "\033[34m"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := consoleSeverityColor(tt.severity)
+			FailIfNotEqual(t, tt.expect, actual)
+		})
+	}
+}
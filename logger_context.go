@@ -0,0 +1,53 @@
+package rec
+
+import "context"
+
+// PrintContext outputs the log entry for the passed rec.Severity with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) PrintContext(ctx context.Context, severity Severity, message string, fields ...Field) {
+	l.write(severity, message, l.appendContextFields(ctx, severity, fields)...)
+}
+
+// DefaultContext outputs the DEFAULT Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) DefaultContext(ctx context.Context, message string, fields ...Field) {
+	l.write(DEFAULT, message, l.appendContextFields(ctx, DEFAULT, fields)...)
+}
+
+// DebugContext outputs the DEBUG Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) DebugContext(ctx context.Context, message string, fields ...Field) {
+	l.write(DEBUG, message, l.appendContextFields(ctx, DEBUG, fields)...)
+}
+
+// InfoContext outputs the INFO Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) InfoContext(ctx context.Context, message string, fields ...Field) {
+	l.write(INFO, message, l.appendContextFields(ctx, INFO, fields)...)
+}
+
+// NoticeContext outputs the NOTICE Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) NoticeContext(ctx context.Context, message string, fields ...Field) {
+	l.write(NOTICE, message, l.appendContextFields(ctx, NOTICE, fields)...)
+}
+
+// WarningContext outputs the WARNING Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) WarningContext(ctx context.Context, message string, fields ...Field) {
+	l.write(WARNING, message, l.appendContextFields(ctx, WARNING, fields)...)
+}
+
+// ErrorContext outputs the ERROR Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) ErrorContext(ctx context.Context, message string, fields ...Field) {
+	l.write(ERROR, message, l.appendContextFields(ctx, ERROR, fields)...)
+}
+
+// CriticalContext outputs the CRITICAL Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) CriticalContext(ctx context.Context, message string, fields ...Field) {
+	l.write(CRITICAL, message, l.appendContextFields(ctx, CRITICAL, fields)...)
+}
+
+// AlertContext outputs the ALERT Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) AlertContext(ctx context.Context, message string, fields ...Field) {
+	l.write(ALERT, message, l.appendContextFields(ctx, ALERT, fields)...)
+}
+
+// EmergencyContext outputs the EMERGENCY Severity log entry with rec.Fields that ctx has by ContextWithFields.
+func (l *Logger) EmergencyContext(ctx context.Context, message string, fields ...Field) {
+	l.write(EMERGENCY, message, l.appendContextFields(ctx, EMERGENCY, fields)...)
+}
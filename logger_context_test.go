@@ -0,0 +1,59 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestLogger_PrintContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextWithFields(context.Background(), String("requestId", "abc"))
+
+	tests := []struct {
+		name     string
+		fn       func(l *Logger)
+		severity string
+	}{
+		{"success(PrintContext)", func(l *Logger) { l.PrintContext(ctx, NOTICE, "test", Int("key", 1)) }, "NOTICE"},
+		{"success(DefaultContext)", func(l *Logger) { l.DefaultContext(ctx, "test", Int("key", 1)) }, "DEFAULT"},
+		{"success(DebugContext)", func(l *Logger) { l.DebugContext(ctx, "test", Int("key", 1)) }, "DEBUG"},
+		{"success(InfoContext)", func(l *Logger) { l.InfoContext(ctx, "test", Int("key", 1)) }, "INFO"},
+		{"success(NoticeContext)", func(l *Logger) { l.NoticeContext(ctx, "test", Int("key", 1)) }, "NOTICE"},
+		{"success(WarningContext)", func(l *Logger) { l.WarningContext(ctx, "test", Int("key", 1)) }, "WARNING"},
+		{"success(ErrorContext)", func(l *Logger) { l.ErrorContext(ctx, "test", Int("key", 1)) }, "ERROR"},
+		{"success(CriticalContext)", func(l *Logger) { l.CriticalContext(ctx, "test", Int("key", 1)) }, "CRITICAL"},
+		{"success(AlertContext)", func(l *Logger) { l.AlertContext(ctx, "test", Int("key", 1)) }, "ALERT"},
+		{"success(EmergencyContext)", func(l *Logger) { l.EmergencyContext(ctx, "test", Int("key", 1)) }, "EMERGENCY"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := bytes.NewBuffer(nil)
+			l := Must(New(buf, WithUseTimestampField(false)))
+			tt.fn(l)
+
+			expect := regexp.MustCompile(`^{"severity":"` + tt.severity + `","caller":"[^"]+/logger_context_test.go:[0-9]+","message":"test","requestId":"abc","key":1}` + defaultLineSeparator + `$`)
+			actual := buf.String()
+			FailIfNotRegexpMatchString(t, expect, actual)
+		})
+	}
+
+	t.Run("success(NoContextFields)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithSeverityThreshold(INFO)))
+		l.InfoContext(context.Background(), "test", Int("key", 1))
+		l.DebugContext(ctx, "test")
+
+		const expect = `{"severity":"INFO","message":"test","key":1}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+}
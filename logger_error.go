@@ -1,9 +1,6 @@
 package rec
 
-import (
-	"errors"
-	"time"
-)
+import "errors"
 
 type errorLogger struct {
 	l *Logger
@@ -44,7 +41,7 @@ func (e *errorLogger) Print(severity Severity, err error, fields ...Field) *erro
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), severity, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(severity, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -61,7 +58,7 @@ func (e *errorLogger) Fatal(severity Severity, err error, fields ...Field) {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), severity, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(severity, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	exitFn(1)
 }
@@ -78,7 +75,7 @@ func (e *errorLogger) Panic(severity Severity, err error, fields ...Field) {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), severity, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(severity, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	panic(err)
 }
@@ -95,7 +92,7 @@ func (e *errorLogger) Default(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), DEFAULT, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(DEFAULT, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -112,7 +109,7 @@ func (e *errorLogger) Debug(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), DEBUG, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(DEBUG, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -129,7 +126,7 @@ func (e *errorLogger) Info(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), INFO, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(INFO, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -146,7 +143,7 @@ func (e *errorLogger) Notice(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), NOTICE, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(NOTICE, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -163,7 +160,7 @@ func (e *errorLogger) Warning(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), WARNING, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(WARNING, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -180,7 +177,7 @@ func (e *errorLogger) Error(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), ERROR, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(ERROR, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -197,7 +194,7 @@ func (e *errorLogger) Critical(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), CRITICAL, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(CRITICAL, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -214,7 +211,7 @@ func (e *errorLogger) Alert(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), ALERT, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(ALERT, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -231,7 +228,7 @@ func (e *errorLogger) Emergency(err error, fields ...Field) *errorReturner {
 		message = err.Error()
 	}
 
-	e.l.write(time.Now(), EMERGENCY, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
+	e.l.write(EMERGENCY, message, append([]Field{Error(err), ErrorStacktrace(err)}, fields...)...)
 
 	return &errorReturner{err}
 }
@@ -0,0 +1,170 @@
+package rec
+
+import (
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// nolint: cyclop
+func (l *Logger) appendJSONEntry(dst []byte, now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) []byte {
+	// NOTE: rec.Error and rec.ErrorStacktrace of the same key added by With and the log entry are merged by encoding them together.
+	if l.config.ErrorFieldFormat == ErrorFormatECS && l.mergesContextECSErrorField(fields) {
+		return l.withFieldsInContext(fields).appendJSONEntry(dst, now, severity, frame, message, nil)
+	}
+
+	// {
+	dst = append(dst, '{')
+
+	// {"timestamp":"...",
+	if l.config.UseTimestampField && !now.IsZero() {
+		dst = append(dst, '"')
+		dst = appendJSONEscapedString(dst, l.config.TimestampFieldKey)
+		dst = append(dst, `":`...)
+		dst = appendTimeFieldValue(dst, now, l.config.TimestampFieldFormat)
+		dst = append(dst, ',')
+	}
+
+	// {"timestamp":"...","severity":"...",
+	if l.config.UseSeverityField {
+		dst = append(dst, '"')
+		dst = appendJSONEscapedString(dst, l.config.SeverityFieldKey)
+		dst = append(dst, `":"`...)
+
+		if l.config.UseUppercaseSeverity {
+			dst = appendJSONEscapedString(dst, l.uppercase(severity))
+		} else {
+			dst = appendJSONEscapedString(dst, l.lowercase(severity))
+		}
+
+		dst = append(dst, `",`...)
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...",
+	if l.config.UseHostnameField {
+		dst = append(dst, '"')
+		dst = appendJSONEscapedString(dst, l.config.HostnameFieldKey)
+		dst = append(dst, `":"`...)
+		dst = appendJSONEscapedString(dst, l.config.HostnameFieldValue)
+		dst = append(dst, `",`...)
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...",
+	if l.config.UseCallerField && !isZeroFrame(frame) {
+		dst = append(dst, '"')
+		dst = appendJSONEscapedString(dst, l.config.CallerFieldKey)
+		dst = append(dst, `":`...)
+		dst = appendJSONCaller(dst, frame, l.config.CallerFieldFormat, l.config.UseShortCaller)
+		dst = append(dst, ',')
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...",
+	if l.config.UseMessageField {
+		dst = append(dst, '"')
+		dst = appendJSONEscapedString(dst, l.config.MessageFieldKey)
+		dst = append(dst, `":"`...)
+		dst = appendJSONEscapedString(dst, l.config.Redactor.maskString(message))
+		dst = append(dst, `",`...)
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","ecs.version":"...",
+	if l.config.ECSVersion != "" {
+		dst = append(dst, `"`+ecsVersionFieldKey+`":"`...)
+		dst = appendJSONEscapedString(dst, l.config.ECSVersion)
+		dst = append(dst, `",`...)
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...",
+	if len(l.contextFields) > 0 {
+		dst = append(dst, l.contextFields...)
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...","fields":"...",
+	namespaces := l.namespaces
+
+	for i := range fields {
+		field, ok := l.sensitiveField(fields[i])
+		if !ok {
+			continue
+		}
+
+		ecs := l.config.ErrorFieldFormat == ErrorFormatECS && isECSErrorField(field)
+		if ecs && isMergedECSErrorField(fields, i) {
+			continue
+		}
+
+		// {"timestamp":"...",...,"context":"...","namespace":{
+		if namespaces == l.namespaces && l.pendingNamespaceCount > 0 {
+			dst = append(dst, l.pendingNamespaces...)
+			namespaces += l.pendingNamespaceCount
+		}
+
+		start := len(dst)
+
+		if ecs {
+			dst = appendECSErrorField(dst, field)
+		} else {
+			dst = appendJSONField(dst, field)
+		}
+
+		dst = append(l.config.Redactor.redactJSONField(dst, start), ',')
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...","fields":"..."
+	if dst[len(dst)-1] == ',' {
+		dst = dst[:len(dst)-1]
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","namespace":{"context":"...","fields":"..."}
+	for i := 0; i < namespaces; i++ {
+		dst = append(dst, '}')
+	}
+
+	// {"timestamp":"...","severity":"...","hostname":"...","caller":"...","message":"...","context":"...","fields":"..."}
+	dst = append(dst, '}')
+
+	return dst
+}
+
+// appendJSONCaller appends the caller field value in the format.
+func appendJSONCaller(dst []byte, frame runtime.Frame, format CallerFormat, useShortCaller bool) []byte {
+	const base = 10
+
+	switch format {
+	case CallerFormatECS:
+		// {"file":{"name":"...","line":10},"function":"..."}
+		dst = append(dst, `{"file":{"name":"`...)
+		if useShortCaller {
+			dst = appendJSONEscapedString(dst, extractShortPath(frame.File))
+		} else {
+			dst = appendJSONEscapedString(dst, frame.File)
+		}
+		dst = append(dst, `","line":`...)
+		dst = strconv.AppendInt(dst, int64(frame.Line), base)
+		dst = append(dst, `},"function":"`...)
+		dst = appendJSONEscapedString(dst, frame.Function)
+		dst = append(dst, `"}`...)
+	case CallerFormatSourceLocation:
+		// {"file":"...","line":"...","function":"..."}
+		dst = append(dst, `{"file":"`...)
+		if useShortCaller {
+			dst = appendJSONEscapedString(dst, extractShortPath(frame.File))
+		} else {
+			dst = appendJSONEscapedString(dst, frame.File)
+		}
+		dst = append(dst, `","line":"`...)
+		dst = strconv.AppendInt(dst, int64(frame.Line), base)
+		dst = append(dst, `","function":"`...)
+		dst = appendJSONEscapedString(dst, frame.Function)
+		dst = append(dst, `"}`...)
+	case CallerFormatString:
+		fallthrough
+	default:
+		// "file:line"
+		dst = append(dst, '"')
+		dst = appendCallerFromFrame(dst, frame, useShortCaller)
+		dst = append(dst, '"')
+	}
+
+	return dst
+}
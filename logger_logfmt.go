@@ -0,0 +1,176 @@
+package rec
+
+import (
+	"encoding/json"
+	"runtime"
+	"time"
+)
+
+// appendLogfmtEntry appends a logfmt log entry like the following:
+//
+//	timestamp=2009-11-10T23:00:00Z severity=INFO caller=main.go:10 message="logfmt logger" key=value
+//
+// nolint: cyclop
+func (l *Logger) appendLogfmtEntry(dst []byte, now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) []byte {
+	start := len(dst)
+
+	// timestamp=...
+	if l.config.UseTimestampField && !now.IsZero() {
+		dst = appendLogfmtKey(appendConsoleSeparator(dst, start), "", l.config.TimestampFieldKey)
+		valueStart := len(dst)
+		dst = appendLogfmtQuotedIfNeeded(appendConsoleTime(dst, now, l.config.TimestampFieldFormat), valueStart)
+	}
+
+	// timestamp=... severity=...
+	if l.config.UseSeverityField {
+		dst = appendLogfmtKey(appendConsoleSeparator(dst, start), "", l.config.SeverityFieldKey)
+
+		if l.config.UseUppercaseSeverity {
+			dst = appendLogfmtString(dst, l.uppercase(severity))
+		} else {
+			dst = appendLogfmtString(dst, l.lowercase(severity))
+		}
+	}
+
+	// timestamp=... severity=... hostname=...
+	if l.config.UseHostnameField {
+		dst = appendLogfmtKey(appendConsoleSeparator(dst, start), "", l.config.HostnameFieldKey)
+		dst = appendLogfmtString(dst, l.config.HostnameFieldValue)
+	}
+
+	// timestamp=... severity=... hostname=... caller=...
+	if l.config.UseCallerField && !isZeroFrame(frame) {
+		dst = appendLogfmtKey(appendConsoleSeparator(dst, start), "", l.config.CallerFieldKey)
+		valueStart := len(dst)
+		dst = appendLogfmtQuotedIfNeeded(appendCallerFromFrame(dst, frame, l.config.UseShortCaller), valueStart)
+	}
+
+	// timestamp=... severity=... hostname=... caller=... message=...
+	if l.config.UseMessageField {
+		dst = appendLogfmtKey(appendConsoleSeparator(dst, start), "", l.config.MessageFieldKey)
+		dst = appendLogfmtString(dst, l.config.Redactor.maskString(message))
+	}
+
+	// timestamp=... severity=... hostname=... caller=... message=... context=...
+	for i := range l.contextFieldList {
+		field, ok := l.sensitiveField(l.contextFieldList[i])
+		if !ok {
+			continue
+		}
+
+		dst = appendLogfmtField(appendConsoleSeparator(dst, start), "", field, l.config.Redactor)
+	}
+
+	// timestamp=... severity=... hostname=... caller=... message=... context=... field=...
+	for i := range fields {
+		field, ok := l.sensitiveField(fields[i])
+		if !ok {
+			continue
+		}
+
+		dst = appendLogfmtField(appendConsoleSeparator(dst, start), l.namespacePrefix, field, l.config.Redactor)
+	}
+
+	return dst
+}
+
+// appendLogfmtKey appends `key=`. The characters that cannot be used in logfmt key are replaced with `_`.
+func appendLogfmtKey(dst []byte, prefix, key string) []byte {
+	for _, s := range [...]string{prefix, key} {
+		for i := 0; i < len(s); i++ {
+			if s[i] <= ' ' || s[i] == '=' || s[i] == '"' {
+				dst = append(dst, '_')
+
+				continue
+			}
+
+			dst = append(dst, s[i])
+		}
+	}
+
+	return append(dst, '=')
+}
+
+// appendLogfmtField appends `key=value`.
+//
+// The value is rendered in the same way as JSON, then:
+//   - JSON string that does not need quoting in logfmt is unquoted.
+//   - JSON array and JSON object are quoted as logfmt string.
+func appendLogfmtField(dst []byte, prefix string, f Field, redactor *Redactor) []byte {
+	dst = appendLogfmtKey(dst, prefix, f.key)
+
+	start := len(dst)
+	dst = appendFieldValue(dst, f, json.Marshal)
+	dst = redactor.redactValue(dst, start, f.key)
+
+	switch dst[start] {
+	case '"':
+		// NOTE: JSON string is also valid logfmt quoted string.
+		if inner := dst[start+1 : len(dst)-1]; len(inner) > 0 && !logfmtNeedsQuoteBytes(inner) {
+			copy(dst[start:], inner)
+			dst = dst[:len(dst)-2]
+		}
+	case '[', '{':
+		dst = appendLogfmtQuoted(dst, start)
+	}
+
+	return dst
+}
+
+// appendLogfmtString appends s as logfmt value.
+func appendLogfmtString(dst []byte, s string) []byte {
+	if s != "" && !logfmtNeedsQuote(s) {
+		return append(dst, s...)
+	}
+
+	return append(appendJSONEscapedString(append(dst, '"'), s), '"')
+}
+
+// appendLogfmtQuotedIfNeeded quotes dst[start:] if needed.
+func appendLogfmtQuotedIfNeeded(dst []byte, start int) []byte {
+	if len(dst) > start && !logfmtNeedsQuoteBytes(dst[start:]) {
+		return dst
+	}
+
+	return appendLogfmtQuoted(dst, start)
+}
+
+// appendLogfmtQuoted quotes dst[start:] that does not contain control characters.
+func appendLogfmtQuoted(dst []byte, start int) []byte {
+	b := bufferPool.Get().(*buffer) // nolint: forcetypeassert
+	defer bufferPool.Put(b)
+
+	b.Buffer = append(b.Buffer[:0], dst[start:]...)
+
+	dst = append(dst[:start], '"')
+
+	for _, c := range b.Buffer {
+		if c == '"' || c == '\\' {
+			dst = append(dst, '\\')
+		}
+
+		dst = append(dst, c)
+	}
+
+	return append(dst, '"')
+}
+
+func logfmtNeedsQuote(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] <= ' ' || s[i] == '=' || s[i] == '"' || s[i] == '\\' {
+			return true
+		}
+	}
+
+	return false
+}
+
+func logfmtNeedsQuoteBytes(b []byte) bool {
+	for _, c := range b {
+		if c <= ' ' || c == '=' || c == '"' || c == '\\' {
+			return true
+		}
+	}
+
+	return false
+}
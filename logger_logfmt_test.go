@@ -0,0 +1,92 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLogger_appendLogfmtEntry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Fields)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatLogfmt), WithUseCallerField(false), WithTimestampFieldKey("time"), WithMessageFieldKey("msg")))
+
+		l.With(String("context", "value")).WithNamespace("http").With(String("method", "GET")).writeAt(testTimestampValue, WARNING, "test message",
+			Int("status", 200),
+			String("empty", ""),
+			String("quote", `"a=b"`),
+			Strings("strings", []string{"a", "b c"}),
+			Errors([]error{errForTest}),
+			ErrorStacktrace(fmt.Errorf("wrap: %w", errForTest)),
+			Group("group", Bool("bool", true)),
+			StringPtr("nil", nil),
+			Float64("nan", math.NaN()),
+			String("key with space", "value"),
+		)
+
+		const expect = `time=2021-01-01T10:23:45.6789+09:00 severity=WARNING msg="test message" context=value http.method=GET http.status=200 http.empty="" http.quote="\"a=b\"" http.strings="[\"a\",\"b c\"]" http.errors="[\"test error\"]" http.errorStacktrace="wrap: test error" http.group="{\"bool\":true}" http.nil=null http.nan=NaN http.key_with_space=value` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(QuotedConfig)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatLogfmt), WithTimestampFieldFormat("2006-01-02 15:04:05"), WithUseUppercaseSeverity(false), WithUseHostnameField(true), WithHostnameFieldValue("local host")))
+
+		l.writeAt(time.Date(2021, 1, 1, 10, 23, 45, 0, time.UTC), ERROR, "multi\nline")
+
+		expect := regexp.MustCompile(`^timestamp="2021-01-01 10:23:45" severity=error hostname="local host" caller=[^ ]+:[0-9]+ message="multi\\nline"` + defaultLineSeparator + `$`)
+		actual := buf.String()
+		FailIfNotRegexpMatchString(t, expect, actual)
+	})
+
+	t.Run("success(NoField)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf))
+		l.config = &Config{Format: FormatLogfmt}
+
+		l.writeAt(testTimestampValue, DEFAULT, "test")
+
+		const expect = ``
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+}
+
+func Test_appendLogfmtQuotedIfNeeded(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		value  string
+		expect string
+	}{
+		{"bare", "value", "key=value"},
+		{"empty", "", `key=""`},
+		{"space", "a b", `key="a b"`},
+		{"quote", `a"b\c`, `key="a\"b\\c"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dst := []byte("key=")
+			actual := appendLogfmtQuotedIfNeeded(append(dst, tt.value...), len(dst))
+			FailIfNotEqual(t, tt.expect, string(actual))
+		})
+	}
+}
@@ -1,70 +1,67 @@
 package rec
 
-import (
-	"os"
-	"time"
-)
+import "os"
 
 var exitFn = os.Exit // nolint: gochecknoglobals
 
 // Print outputs the log entry for the passed rec.Severity.
 func (l *Logger) Print(severity Severity, message string, fields ...Field) {
-	l.write(time.Now(), severity, message, fields...)
+	l.write(severity, message, fields...)
 }
 
 // Fatal outputs the log entry for the passed rec.Severity and call os.Exit(1).
 func (l *Logger) Fatal(severity Severity, message string, fields ...Field) {
-	l.write(time.Now(), severity, message, fields...)
+	l.write(severity, message, fields...)
 	exitFn(1)
 }
 
 // Panic outputs the log entry for the passed rec.Severity and call panic(message).
 func (l *Logger) Panic(severity Severity, message string, fields ...Field) {
-	l.write(time.Now(), severity, message, fields...)
+	l.write(severity, message, fields...)
 	panic(message)
 }
 
 // Default outputs the DEFAULT Severity log entry.
 func (l *Logger) Default(message string, fields ...Field) {
-	l.write(time.Now(), DEFAULT, message, fields...)
+	l.write(DEFAULT, message, fields...)
 }
 
 // Debug outputs the DEBUG Severity log entry.
 func (l *Logger) Debug(message string, fields ...Field) {
-	l.write(time.Now(), DEBUG, message, fields...)
+	l.write(DEBUG, message, fields...)
 }
 
 // Info outputs the INFO Severity log entry.
 func (l *Logger) Info(message string, fields ...Field) {
-	l.write(time.Now(), INFO, message, fields...)
+	l.write(INFO, message, fields...)
 }
 
 // Notice outputs the NOTICE Severity log entry.
 func (l *Logger) Notice(message string, fields ...Field) {
-	l.write(time.Now(), NOTICE, message, fields...)
+	l.write(NOTICE, message, fields...)
 }
 
 // Warning outputs the WARNING Severity log entry.
 func (l *Logger) Warning(message string, fields ...Field) {
-	l.write(time.Now(), WARNING, message, fields...)
+	l.write(WARNING, message, fields...)
 }
 
 // Error outputs the ERROR Severity log entry.
 func (l *Logger) Error(message string, fields ...Field) {
-	l.write(time.Now(), ERROR, message, fields...)
+	l.write(ERROR, message, fields...)
 }
 
 // Critical outputs the CRITICAL Severity log entry.
 func (l *Logger) Critical(message string, fields ...Field) {
-	l.write(time.Now(), CRITICAL, message, fields...)
+	l.write(CRITICAL, message, fields...)
 }
 
 // Alert outputs the ALERT Severity log entry.
 func (l *Logger) Alert(message string, fields ...Field) {
-	l.write(time.Now(), ALERT, message, fields...)
+	l.write(ALERT, message, fields...)
 }
 
 // Emergency outputs the EMERGENCY Severity log entry.
 func (l *Logger) Emergency(message string, fields ...Field) {
-	l.write(time.Now(), EMERGENCY, message, fields...)
+	l.write(EMERGENCY, message, fields...)
 }
@@ -2,7 +2,9 @@
 package rec
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -246,6 +248,43 @@ func TestLogger_AddContextFields(t *testing.T) {
 	}
 }
 
+func TestLogger_WithNamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		logger func(l *Logger) *Logger
+		field  []Field
+		expect string
+	}{
+		{"success()", func(l *Logger) *Logger { return l.WithNamespace("http") }, []Field{String("method", "GET"), Int("status", 200)}, `{"severity":"INFO","message":"test","http":{"method":"GET","status":200}}` + defaultLineSeparator},
+		{"success(With)", func(l *Logger) *Logger {
+			return l.With(String("field", "context")).WithNamespace("http").With(String("method", "GET"))
+		}, []Field{Int("status", 200)}, `{"severity":"INFO","message":"test","field":"context","http":{"method":"GET","status":200}}` + defaultLineSeparator},
+		{"success(nested)", func(l *Logger) *Logger { return l.WithNamespace("a").WithNamespace("b").Copy() }, []Field{Int("c", 1)}, `{"severity":"INFO","message":"test","a":{"b":{"c":1}}}` + defaultLineSeparator},
+		{"success(empty)", func(l *Logger) *Logger { return l.WithNamespace("http") }, nil, `{"severity":"INFO","message":"test"}` + defaultLineSeparator},
+		{"success(emptyNested)", func(l *Logger) *Logger {
+			return l.WithNamespace("a").With(Int("b", 1)).WithNamespace("c").WithNamespace("d")
+		}, nil, `{"severity":"INFO","message":"test","a":{"b":1}}` + defaultLineSeparator},
+		{"success(pendingWith)", func(l *Logger) *Logger {
+			return l.WithNamespace("a").WithNamespace("b").With(Int("c", 1)).WithNamespace("d")
+		}, []Field{Int("e", 2)}, `{"severity":"INFO","message":"test","a":{"b":{"c":1,"d":{"e":2}}}}` + defaultLineSeparator},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := bytes.NewBuffer(nil)
+			l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+
+			tt.logger(l).Info("test", tt.field...)
+			actual := buf.String()
+			FailIfNotEqual(t, tt.expect, actual)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	t.Parallel()
 
@@ -318,7 +357,7 @@ func TestLogger_print(t *testing.T) {
 		// prepare
 		l := Must(New(devnull, WithSeverityThreshold(1)))
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 	})
 
 	t.Run("success(UppercaseSeverity)", func(t *testing.T) {
@@ -327,7 +366,7 @@ func TestLogger_print(t *testing.T) {
 		// prepare
 		l := Must(New(devnull))
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 	})
 
 	t.Run("success(LowercaseSeverity)", func(t *testing.T) {
@@ -336,7 +375,7 @@ func TestLogger_print(t *testing.T) {
 		// prepare
 		l := Must(New(devnull, WithUseUppercaseSeverity(false)))
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 	})
 
 	t.Run("success(UseShortCaller)", func(t *testing.T) {
@@ -345,7 +384,7 @@ func TestLogger_print(t *testing.T) {
 		// prepare
 		l := Must(New(devnull))
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 	})
 
 	t.Run("success(UseLongCaller)", func(t *testing.T) {
@@ -354,7 +393,7 @@ func TestLogger_print(t *testing.T) {
 		// prepare
 		l := Must(New(devnull, WithUseShortCaller(false)))
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 	})
 
 	t.Run("success(UseHostnameField=true)", func(t *testing.T) {
@@ -363,7 +402,7 @@ func TestLogger_print(t *testing.T) {
 		// prepare
 		l := Must(New(devnull, WithUseHostnameField(true)))
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 	})
 
 	t.Run("success(UseHostnameField=false)", func(t *testing.T) {
@@ -372,7 +411,7 @@ func TestLogger_print(t *testing.T) {
 		// prepare
 		l := Must(New(devnull))
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 	})
 
 	t.Run("success(NoField)", func(t *testing.T) {
@@ -383,7 +422,7 @@ func TestLogger_print(t *testing.T) {
 		l := Must(New(buf))
 		l.config = &Config{}
 		// run
-		l.write(time.Now(), DEFAULT, testLogEntryMessage)
+		l.writeAt(time.Now(), DEFAULT, testLogEntryMessage)
 		// check
 		const expect = `{}`
 		actual := buf.String()
@@ -397,7 +436,7 @@ func TestLogger_print(t *testing.T) {
 		buf := bytes.NewBuffer(nil)
 		l := Must(New(buf, WithUseCallerField(false)))
 		// run
-		l.write(testTimestampValue, DEFAULT, testLogEntryMessage, Field{key: "noneField"})
+		l.writeAt(testTimestampValue, DEFAULT, testLogEntryMessage, Field{key: "noneField"})
 		// check
 		const expect = `{"timestamp":"2021-01-01T10:23:45.6789+09:00","severity":"DEFAULT","message":"` + testLogEntryMessageJSONEscape + `","noneField":"ERROR: TYPE NONE"}` + defaultLineSeparator
 		actualErr := buf.String()
@@ -411,7 +450,7 @@ func TestLogger_print(t *testing.T) {
 		buf := bytes.NewBuffer(nil)
 		l := Must(New(buf, WithUseCallerField(false)))
 		// run
-		l.write(testTimestampValue, DEFAULT, testLogEntryMessage, Field{key: "undefinedField", t: math.MaxUint8})
+		l.writeAt(testTimestampValue, DEFAULT, testLogEntryMessage, Field{key: "undefinedField", t: math.MaxUint8})
 		// check
 		const expect = `{"timestamp":"2021-01-01T10:23:45.6789+09:00","severity":"DEFAULT","message":"` + testLogEntryMessageJSONEscape + `","undefinedField":"ERROR: UNDEFINED TYPE: 255"}` + defaultLineSeparator
 		actual := buf.String()
@@ -425,7 +464,7 @@ func TestLogger_print(t *testing.T) {
 		buf := bytes.NewBuffer(nil)
 		l := Must(New(buf, WithTimestampFieldFormat(""), WithUseHostnameField(true)))
 		// run
-		l.write(testTimestampValue, INFO, testLogEntryMessage)
+		l.writeAt(testTimestampValue, INFO, testLogEntryMessage)
 		// check
 		expect := regexp.MustCompile(`^{"timestamp":1609464225.6789,"severity":"INFO","hostname":".+","caller":"[^"]+:[0-9]+","message":".+"}` + l.config.LineSeparator)
 		actual := buf.String()
@@ -446,7 +485,7 @@ func TestLogger_print(t *testing.T) {
 		// run
 		noSuchFile, _ := os.OpenFile("/tmp/no/such/file", os.O_RDWR, 0o600)
 		l := Must(New(noSuchFile))
-		l.write(testTimestampValue, DEFAULT, testLogEntryMessage)
+		l.writeAt(testTimestampValue, DEFAULT, testLogEntryMessage)
 		// check
 		const expect = `{"timestamp":"2021-01-01T10:23:45.6789+09:00","severity":"ERROR","message":"(*rec.Logger).write: writer=(*os.File)(nil): Write: invalid argument","error":"(*rec.Logger).write: writer=(*os.File)(nil): Write: invalid argument"}` + defaultLineSeparator
 		actual := buf.String()
@@ -502,3 +541,37 @@ func TestReplaceDefaultLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger_Flush(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(bufio)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(bufio.NewWriter(buf), WithUseTimestampField(false), WithUseCallerField(false)))
+		l.Info("test")
+		FailIfNotEqual(t, "", buf.String())
+
+		if err := l.Flush(context.Background()); err != nil {
+			t.Errorf("Flush: %v", err)
+		}
+
+		FailIfNotEqual(t, `{"severity":"INFO","message":"test"}`+defaultLineSeparator, buf.String())
+	})
+
+	t.Run("success(noop)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(io.Discard))
+		if err := l.Flush(context.Background()); err != nil {
+			t.Errorf("Flush: %v", err)
+		}
+
+		if err := l.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+
+		FailIfNotEqual(t, uint64(0), l.Dropped())
+	})
+}
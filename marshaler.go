@@ -0,0 +1,242 @@
+package rec
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ObjectMarshaler is implemented by types that can append themselves to the log entry as JSON Object without reflection.
+// If MarshalRecObject panics with a nil pointer, e.g. `(*User)(nil)`, the value is rendered as `null`.
+// MarshalRecObject of pointer receiver can handle nil by itself, because it is called without reflection.
+//
+//	type User struct {
+//	    ID   int64
+//	    Name string
+//	}
+//
+//	func (u *User) MarshalRecObject(enc *rec.ObjectEncoder) {
+//	    enc.AddInt64("id", u.ID)
+//	    enc.AddString("name", u.Name)
+//	}
+type ObjectMarshaler interface {
+	MarshalRecObject(enc *ObjectEncoder)
+}
+
+// ArrayMarshaler is implemented by types that can append themselves to the log entry as JSON Array without reflection.
+// If MarshalRecArray panics with a nil pointer, the value is rendered as `null`.
+//
+//	type Users []*User
+//
+//	func (us Users) MarshalRecArray(enc *rec.ArrayEncoder) {
+//	    for _, u := range us {
+//	        enc.AppendObject(u)
+//	    }
+//	}
+type ArrayMarshaler interface {
+	MarshalRecArray(enc *ArrayEncoder)
+}
+
+// ObjectEncoder appends the members of JSON Object to the buffer of the log entry.
+type ObjectEncoder struct {
+	buf           []byte
+	jsonMarshalFn func(interface{}) ([]byte, error)
+}
+
+// ArrayEncoder appends the elements of JSON Array to the buffer of the log entry.
+type ArrayEncoder struct {
+	buf           []byte
+	jsonMarshalFn func(interface{}) ([]byte, error)
+}
+
+// isNilPointer reports whether value is a typed nil pointer, e.g. `(*User)(nil)`, that is not equal to nil as interface.
+// NOTE: it is called only when the marshaler panics, so that the marshalers cost no reflection.
+func isNilPointer(value interface{}) bool {
+	v := reflect.ValueOf(value)
+
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// recoverNilPointer recovers the panic of the marshaler called with a typed nil pointer, e.g. the method of value receiver, and returns true.
+// The other panics are not recovered.
+func recoverNilPointer(r interface{}, value interface{}) bool {
+	if r == nil {
+		return false
+	}
+
+	if !isNilPointer(value) {
+		panic(r)
+	}
+
+	return true
+}
+
+func appendObjectMarshaler(dst []byte, value ObjectMarshaler, jsonMarshalFn func(interface{}) ([]byte, error)) (result []byte) {
+	enc := objectEncoderPool.Get().(*ObjectEncoder) // nolint: forcetypeassert
+	defer objectEncoderPool.Put(enc)
+
+	// NOTE: a typed nil pointer renders as null like a nil interface.
+	defer func() {
+		if recoverNilPointer(recover(), value) {
+			enc.buf, enc.jsonMarshalFn = nil, nil
+			result = append(dst, "null"...)
+		}
+	}()
+
+	enc.buf = append(dst, '{')
+	enc.jsonMarshalFn = jsonMarshalFn
+
+	value.MarshalRecObject(enc)
+
+	dst = enc.buf
+	enc.buf = nil
+	enc.jsonMarshalFn = nil
+
+	if dst[len(dst)-1] == ',' {
+		dst[len(dst)-1] = '}'
+	} else {
+		dst = append(dst, '}')
+	}
+
+	return dst
+}
+
+func appendArrayMarshaler(dst []byte, value ArrayMarshaler, jsonMarshalFn func(interface{}) ([]byte, error)) (result []byte) {
+	enc := arrayEncoderPool.Get().(*ArrayEncoder) // nolint: forcetypeassert
+	defer arrayEncoderPool.Put(enc)
+
+	// NOTE: a typed nil pointer renders as null like a nil interface.
+	defer func() {
+		if recoverNilPointer(recover(), value) {
+			enc.buf, enc.jsonMarshalFn = nil, nil
+			result = append(dst, "null"...)
+		}
+	}()
+
+	enc.buf = append(dst, '[')
+	enc.jsonMarshalFn = jsonMarshalFn
+
+	value.MarshalRecArray(enc)
+
+	dst = enc.buf
+	enc.buf = nil
+	enc.jsonMarshalFn = nil
+
+	if dst[len(dst)-1] == ',' {
+		dst[len(dst)-1] = ']'
+	} else {
+		dst = append(dst, ']')
+	}
+
+	return dst
+}
+
+func (enc *ObjectEncoder) appendKey(key string) {
+	enc.buf = append(appendJSONEscapedString(append(enc.buf, '"'), key), '"', ':')
+}
+
+// AddFields adds rec.Field to JSON Object.
+func (enc *ObjectEncoder) AddFields(fields ...Field) {
+	for i := range fields {
+		enc.appendKey(fields[i].key)
+		enc.buf = append(appendFieldValue(enc.buf, fields[i], enc.jsonMarshalFn), ',')
+	}
+}
+
+// AddBool adds bool to JSON Object.
+func (enc *ObjectEncoder) AddBool(key string, value bool) {
+	enc.appendKey(key)
+	enc.buf = append(strconv.AppendBool(enc.buf, value), ',')
+}
+
+// AddInt64 adds int64 to JSON Object.
+func (enc *ObjectEncoder) AddInt64(key string, value int64) {
+	const base = 10
+
+	enc.appendKey(key)
+	enc.buf = append(strconv.AppendInt(enc.buf, value, base), ',')
+}
+
+// AddUint64 adds uint64 to JSON Object.
+func (enc *ObjectEncoder) AddUint64(key string, value uint64) {
+	const base = 10
+
+	enc.appendKey(key)
+	enc.buf = append(strconv.AppendUint(enc.buf, value, base), ',')
+}
+
+// AddFloat64 adds float64 to JSON Object.
+func (enc *ObjectEncoder) AddFloat64(key string, value float64) {
+	const bitSize = 64
+
+	enc.appendKey(key)
+	enc.buf = append(appendFloatFieldValue(enc.buf, value, bitSize), ',')
+}
+
+// AddString adds string to JSON Object.
+func (enc *ObjectEncoder) AddString(key string, value string) {
+	enc.appendKey(key)
+	enc.buf = append(appendJSONEscapedString(append(enc.buf, '"'), value), '"', ',')
+}
+
+// AddTime adds time.Time to JSON Object with rec.CustomTimeFormat.
+func (enc *ObjectEncoder) AddTime(key string, value time.Time) {
+	enc.appendKey(key)
+	enc.buf = append(appendTimeFieldValue(enc.buf, value, CustomTimeFormat), ',')
+}
+
+// AddObject adds rec.ObjectMarshaler to JSON Object.
+func (enc *ObjectEncoder) AddObject(key string, value ObjectMarshaler) {
+	enc.AddFields(Marshaler(key, value))
+}
+
+// AddArray adds rec.ArrayMarshaler to JSON Object.
+func (enc *ObjectEncoder) AddArray(key string, value ArrayMarshaler) {
+	enc.AddFields(Array(key, value))
+}
+
+// AppendBool appends bool to JSON Array.
+func (enc *ArrayEncoder) AppendBool(value bool) {
+	enc.buf = append(strconv.AppendBool(enc.buf, value), ',')
+}
+
+// AppendInt64 appends int64 to JSON Array.
+func (enc *ArrayEncoder) AppendInt64(value int64) {
+	const base = 10
+
+	enc.buf = append(strconv.AppendInt(enc.buf, value, base), ',')
+}
+
+// AppendUint64 appends uint64 to JSON Array.
+func (enc *ArrayEncoder) AppendUint64(value uint64) {
+	const base = 10
+
+	enc.buf = append(strconv.AppendUint(enc.buf, value, base), ',')
+}
+
+// AppendFloat64 appends float64 to JSON Array.
+func (enc *ArrayEncoder) AppendFloat64(value float64) {
+	const bitSize = 64
+
+	enc.buf = append(appendFloatFieldValue(enc.buf, value, bitSize), ',')
+}
+
+// AppendString appends string to JSON Array.
+func (enc *ArrayEncoder) AppendString(value string) {
+	enc.buf = append(appendJSONEscapedString(append(enc.buf, '"'), value), '"', ',')
+}
+
+// AppendTime appends time.Time to JSON Array with rec.CustomTimeFormat.
+func (enc *ArrayEncoder) AppendTime(value time.Time) {
+	enc.buf = append(appendTimeFieldValue(enc.buf, value, CustomTimeFormat), ',')
+}
+
+// AppendObject appends rec.ObjectMarshaler to JSON Array.
+func (enc *ArrayEncoder) AppendObject(value ObjectMarshaler) {
+	enc.buf = append(appendFieldValue(enc.buf, Marshaler("", value), enc.jsonMarshalFn), ',')
+}
+
+// AppendArray appends rec.ArrayMarshaler to JSON Array.
+func (enc *ArrayEncoder) AppendArray(value ArrayMarshaler) {
+	enc.buf = append(appendFieldValue(enc.buf, Array("", value), enc.jsonMarshalFn), ',')
+}
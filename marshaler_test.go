@@ -0,0 +1,196 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type testUser struct {
+	ID      int64
+	Name    string
+	Admin   bool
+	Score   float64
+	Visits  uint64
+	Created time.Time
+	Tags    testTags
+}
+
+func (u *testUser) MarshalRecObject(enc *ObjectEncoder) {
+	enc.AddInt64("id", u.ID)
+	enc.AddString("name", u.Name)
+	enc.AddBool("admin", u.Admin)
+	enc.AddFloat64("score", u.Score)
+	enc.AddUint64("visits", u.Visits)
+	enc.AddTime("created", u.Created)
+	enc.AddArray("tags", u.Tags)
+	enc.AddFields(DurationFormat("duration", time.Minute))
+}
+
+type testTags []string
+
+func (ts testTags) MarshalRecArray(enc *ArrayEncoder) {
+	for _, tag := range ts {
+		enc.AppendString(tag)
+	}
+}
+
+type testUsers []*testUser
+
+func (us testUsers) MarshalRecArray(enc *ArrayEncoder) {
+	for _, u := range us {
+		enc.AppendObject(u)
+	}
+}
+
+type testScalars struct{}
+
+func (testScalars) MarshalRecArray(enc *ArrayEncoder) {
+	enc.AppendBool(true)
+	enc.AppendInt64(-1)
+	enc.AppendUint64(1)
+	enc.AppendFloat64(1.5)
+	enc.AppendTime(time.Unix(0, 0).UTC())
+	enc.AppendArray(testTags{"a"})
+}
+
+type testEmpty struct{}
+
+func (testEmpty) MarshalRecObject(*ObjectEncoder) {}
+
+func (testEmpty) MarshalRecArray(*ArrayEncoder) {}
+
+type testPanic struct{}
+
+func (testPanic) MarshalRecObject(*ObjectEncoder) { panic(errForTest) }
+
+func TestMarshaler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(object)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"user":{"id":1,"name":"\"rec\"","admin":true,"score":1.5,"visits":2,"created":"1970-01-01T00:00:00Z","tags":["a","b"],"duration":"1m0s"}`)
+		actual := appendJSONField(bs, Marshaler("user", &testUser{ID: 1, Name: `"rec"`, Admin: true, Score: 1.5, Visits: 2, Created: time.Unix(0, 0).UTC(), Tags: testTags{"a", "b"}}))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(empty)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"empty":{}`)
+		actual := appendJSONField(bs, Marshaler("empty", testEmpty{}))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(nil)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"user":null`)
+		actual := appendJSONField(bs, Marshaler("user", nil))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(nilPointer)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"user":null`)
+		actual := appendJSONField(bs, Marshaler("user", (*testUser)(nil)))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("failure(panic)", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			FailIfNotEqual(t, errForTest, recover())
+		}()
+
+		_ = appendJSONField(make([]byte, 0, 1024), Marshaler("panic", testPanic{}))
+	})
+}
+
+func TestArray(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(objects)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"users":[{"id":1,"name":"a","admin":false,"score":0,"visits":0,"created":"1970-01-01T00:00:00Z","tags":[],"duration":"1m0s"}]`)
+		actual := appendJSONField(bs, Array("users", testUsers{{ID: 1, Name: "a", Created: time.Unix(0, 0).UTC()}}))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(scalars)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"scalars":[true,-1,1,1.5,"1970-01-01T00:00:00Z",["a"]]`)
+		actual := appendJSONField(bs, Array("scalars", testScalars{}))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(empty)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"empty":[]`)
+		actual := appendJSONField(bs, Array("empty", testEmpty{}))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(nil)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"users":null`)
+		actual := appendJSONField(bs, Array("users", nil))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(nilPointer)", func(t *testing.T) {
+		t.Parallel()
+
+		bs := make([]byte, 0, 1024)
+
+		expect := []byte(`"tags":null,"users":[null]`)
+		actual := appendJSONField(append(appendJSONField(bs, Array("tags", (*testTags)(nil))), ','), Array("users", testUsers{nil}))
+
+		FailIfNotBytesEqual(t, expect, actual)
+	})
+
+	t.Run("success(Logger)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+
+		l.Info("test", Array("tags", testTags{"a"}))
+
+		const expect = `{"severity":"INFO","message":"test","tags":["a"]}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+}
@@ -0,0 +1,337 @@
+package rec
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultRequestIDHeader   = "X-Request-Id"
+	defaultRequestIDFieldKey = "requestId"
+	defaultAccessLogMessage  = "access log"
+	requestIDBytes           = 16
+	requestIDMaxLength       = 128
+)
+
+// httpMiddleware is the configuration of the middleware returned by NewHTTPMiddleware.
+type httpMiddleware struct {
+	l *Logger
+
+	requestIDHeader   string
+	requestIDFieldKey string
+	requestIDFunc     func() string
+	message           string
+	latencyUnit       time.Duration
+	severityFunc      func(statusCode int) Severity
+}
+
+// HTTPMiddlewareOption is a struct that handles the middleware used when NewHTTPMiddleware.
+type HTTPMiddlewareOption struct {
+	name string
+	f    func(*httpMiddleware) error
+}
+
+// WithHTTPMiddlewareRequestIDHeader returns `rec.HTTPMiddlewareOption` for setting the header to propagate the request ID. Default is `X-Request-Id`.
+func WithHTTPMiddlewareRequestIDHeader(header string) HTTPMiddlewareOption {
+	return HTTPMiddlewareOption{
+		name: funcName(),
+		f: func(m *httpMiddleware) error {
+			if header == "" {
+				return fmt.Errorf("header %w", ErrIsEmpty)
+			}
+
+			m.requestIDHeader = header
+
+			return nil
+		},
+	}
+}
+
+// WithHTTPMiddlewareRequestIDFieldKey returns `rec.HTTPMiddlewareOption` for setting the key of the request ID field. Default is `requestId`.
+func WithHTTPMiddlewareRequestIDFieldKey(key string) HTTPMiddlewareOption {
+	return HTTPMiddlewareOption{
+		name: funcName(),
+		f: func(m *httpMiddleware) error {
+			m.requestIDFieldKey = key
+
+			return nil
+		},
+	}
+}
+
+// WithHTTPMiddlewareRequestIDFunc returns `rec.HTTPMiddlewareOption` for setting the function to generate the request ID if the request does not have it.
+// Default is random 128 bits in hex.
+func WithHTTPMiddlewareRequestIDFunc(requestIDFunc func() string) HTTPMiddlewareOption {
+	return HTTPMiddlewareOption{
+		name: funcName(),
+		f: func(m *httpMiddleware) error {
+			if requestIDFunc == nil {
+				return fmt.Errorf("requestIDFunc %w", ErrIsEmpty)
+			}
+
+			m.requestIDFunc = requestIDFunc
+
+			return nil
+		},
+	}
+}
+
+// WithHTTPMiddlewareAccessLogMessage returns `rec.HTTPMiddlewareOption` for setting the message of the access log. Default is `access log`.
+func WithHTTPMiddlewareAccessLogMessage(message string) HTTPMiddlewareOption {
+	return HTTPMiddlewareOption{
+		name: funcName(),
+		f: func(m *httpMiddleware) error {
+			m.message = message
+
+			return nil
+		},
+	}
+}
+
+// WithHTTPMiddlewareLatencyUnit returns `rec.HTTPMiddlewareOption` for setting the unit of the latency field. Default is time.Millisecond.
+func WithHTTPMiddlewareLatencyUnit(unit time.Duration) HTTPMiddlewareOption {
+	return HTTPMiddlewareOption{
+		name: funcName(),
+		f: func(m *httpMiddleware) error {
+			if unit <= 0 {
+				return fmt.Errorf("unit=%s: %w", unit, ErrInvalidDurationUnit)
+			}
+
+			m.latencyUnit = unit
+
+			return nil
+		},
+	}
+}
+
+// WithHTTPMiddlewareSeverityFunc returns `rec.HTTPMiddlewareOption` for setting the function that returns the severity of the access log from the status code.
+// Default is HTTPStatusSeverity.
+func WithHTTPMiddlewareSeverityFunc(severityFunc func(statusCode int) Severity) HTTPMiddlewareOption {
+	return HTTPMiddlewareOption{
+		name: funcName(),
+		f: func(m *httpMiddleware) error {
+			if severityFunc == nil {
+				return fmt.Errorf("severityFunc %w", ErrIsEmpty)
+			}
+
+			m.severityFunc = severityFunc
+
+			return nil
+		},
+	}
+}
+
+// HTTPStatusSeverity returns ERROR for 5xx, WARNING for 4xx, and INFO for others.
+func HTTPStatusSeverity(statusCode int) Severity {
+	switch {
+	case statusCode >= http.StatusInternalServerError:
+		return ERROR
+	case statusCode >= http.StatusBadRequest:
+		return WARNING
+	default:
+		return INFO
+	}
+}
+
+// requestIDCounter is the counter for the request ID when crypto/rand fails.
+var requestIDCounter uint64 // nolint: gochecknoglobals
+
+func newRequestID() string {
+	return newRequestIDFrom(rand.Reader)
+}
+
+func newRequestIDFrom(r io.Reader) string {
+	b := make([]byte, requestIDBytes)
+	if _, err := io.ReadFull(r, b); err != nil {
+		// NOTE: fall back to the time and the counter, so that the request ID is never empty and unique in the process.
+		binary.BigEndian.PutUint64(b, uint64(time.Now().UnixNano()))
+		binary.BigEndian.PutUint64(b[requestIDBytes/2:], atomic.AddUint64(&requestIDCounter, 1))
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// validRequestID reports whether the request ID propagated from the request header is safe to log and to set to the response header.
+// It must be 1 to 128 characters of alphanumerics, `-`, `_`, `.` and `:`.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > requestIDMaxLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		switch c := requestID[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewHTTPMiddleware returns net/http middleware that:
+//
+//   - propagates the request ID from the request header, or generates it, and sets it to the response header.
+//     The request ID that is longer than 128 characters or has the characters other than alphanumerics, `-`, `_`, `.` and `:` is generated again.
+//   - puts `*rec.Logger` that the request ID field is added by With into the request context. It can be taken out by ContextLogger.
+//   - outputs an access log entry that has method, path, status, bytes, latency, remoteAddr and userAgent fields.
+//     The severity of the access log follows the status class. The access log has no caller field, because the caller is always the middleware.
+//     If next panics, the access log is written with status 500 unless the header has been written, and the panic is propagated.
+//     The latency is measured by `config.Clock` of l, so it is always 0 with rec.NewFixedClock. Use rec.NewFakeClock for the tests.
+func NewHTTPMiddleware(l *Logger, options ...HTTPMiddlewareOption) (func(next http.Handler) http.Handler, error) {
+	m := &httpMiddleware{
+		l:                 l,
+		requestIDHeader:   defaultRequestIDHeader,
+		requestIDFieldKey: defaultRequestIDFieldKey,
+		requestIDFunc:     newRequestID,
+		message:           defaultAccessLogMessage,
+		latencyUnit:       time.Millisecond,
+		severityFunc:      HTTPStatusSeverity,
+	}
+
+	for _, opt := range options {
+		if err := opt.f(m); err != nil {
+			return nil, fmt.Errorf("%s: %w", opt.name, err)
+		}
+	}
+
+	return m.handler, nil
+}
+
+func (m *httpMiddleware) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := m.l.now()
+
+		requestID := r.Header.Get(m.requestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = m.requestIDFunc()
+		}
+
+		w.Header().Set(m.requestIDHeader, requestID)
+
+		l := m.l.With(String(m.requestIDFieldKey, requestID))
+		rw := &httpResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		// NOTE: the access log is written by defer without recover, so that it is written even if next panics and the panic keeps its stack trace.
+		panicked := true
+		defer func() {
+			statusCode := rw.statusCode
+			if panicked && !rw.wroteHeader {
+				statusCode = http.StatusInternalServerError
+			}
+
+			l.writeWithoutCaller(m.severityFunc(statusCode), m.message,
+				String("method", r.Method),
+				String("path", r.URL.Path),
+				Int("status", statusCode),
+				Int64("bytes", rw.bytes),
+				Duration("latency", m.latencyUnit, m.l.now().Sub(start)),
+				String("remoteAddr", r.RemoteAddr),
+				String("userAgent", r.UserAgent()),
+			)
+		}()
+
+		next.ServeHTTP(rw.wrap(), r.WithContext(ContextWithLogger(r.Context(), l)))
+
+		panicked = false
+	})
+}
+
+// httpResponseWriter records the status code and the number of bytes written.
+type httpResponseWriter struct {
+	http.ResponseWriter
+
+	wroteHeader bool
+	statusCode  int
+	bytes       int64
+}
+
+// wrap returns w that implements http.Flusher and http.Hijacker only if the underlying http.ResponseWriter implements them,
+// so that the handler can detect the capabilities by type assertion.
+func (w *httpResponseWriter) wrap() http.ResponseWriter {
+	_, flusher := w.ResponseWriter.(http.Flusher)
+	_, hijacker := w.ResponseWriter.(http.Hijacker)
+
+	switch {
+	case flusher && hijacker:
+		return &httpFlushHijackResponseWriter{w}
+	case flusher:
+		return &httpFlushResponseWriter{w}
+	case hijacker:
+		return &httpHijackResponseWriter{w}
+	default:
+		return w
+	}
+}
+
+// WriteHeader records the status code. The informational status codes (1xx) are not recorded, because they are followed by the final status code.
+func (w *httpResponseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader && statusCode >= http.StatusOK {
+		w.wroteHeader = true
+		w.statusCode = statusCode
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *httpResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+
+	return n, err // nolint: wrapcheck
+}
+
+// Unwrap returns the underlying http.ResponseWriter for http.ResponseController.
+func (w *httpResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *httpResponseWriter) flush() {
+	w.wroteHeader = true
+	w.ResponseWriter.(http.Flusher).Flush() // nolint: forcetypeassert
+}
+
+func (w *httpResponseWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.(http.Hijacker).Hijack() // nolint: forcetypeassert, wrapcheck
+}
+
+// httpFlushResponseWriter is httpResponseWriter that implements http.Flusher.
+type httpFlushResponseWriter struct {
+	*httpResponseWriter
+}
+
+func (w *httpFlushResponseWriter) Flush() {
+	w.flush()
+}
+
+// httpHijackResponseWriter is httpResponseWriter that implements http.Hijacker.
+type httpHijackResponseWriter struct {
+	*httpResponseWriter
+}
+
+func (w *httpHijackResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.hijack()
+}
+
+// httpFlushHijackResponseWriter is httpResponseWriter that implements http.Flusher and http.Hijacker.
+type httpFlushHijackResponseWriter struct {
+	*httpResponseWriter
+}
+
+func (w *httpFlushHijackResponseWriter) Flush() {
+	w.flush()
+}
+
+func (w *httpFlushHijackResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.hijack()
+}
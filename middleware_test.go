@@ -0,0 +1,253 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestHTTPStatusSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		statusCode int
+		expect     Severity
+	}{
+		{http.StatusOK, INFO},
+		{http.StatusFound, INFO},
+		{http.StatusNotFound, WARNING},
+		{http.StatusServiceUnavailable, ERROR},
+	}
+	for _, tt := range tests {
+		FailIfNotEqual(t, tt.expect, HTTPStatusSeverity(tt.statusCode))
+	}
+}
+
+type testHijackResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (testHijackResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errForTest
+}
+
+func Test_newRequestIDFrom(t *testing.T) {
+	t.Parallel()
+
+	FailIfNotEqual(t, "00000000000000000000000000000000", newRequestIDFrom(bytes.NewReader(make([]byte, requestIDBytes))))
+
+	// NOTE: the request ID is generated from the time and the counter if the reader fails.
+	first, second := newRequestIDFrom(iotest.ErrReader(errForTest)), newRequestIDFrom(iotest.ErrReader(errForTest))
+	FailIfNotRegexpMatchString(t, regexp.MustCompile(`^[0-9a-f]{32}$`), first)
+	FailIfNotEqual(t, true, first != second)
+}
+
+func TestNewHTTPMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(GenerateRequestID)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		middleware, err := NewHTTPMiddleware(l, WithHTTPMiddlewareRequestIDFunc(func() string { return "generated" }), WithHTTPMiddlewareLatencyUnit(time.Hour))
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ContextLogger(r.Context()).Info("handler")
+			w.WriteHeader(http.StatusCreated)
+			w.WriteHeader(http.StatusInternalServerError) // superfluous
+			_, _ = w.Write([]byte("hello"))
+		}))
+
+		r := httptest.NewRequest(http.MethodPost, "/path?query=value", nil)
+		r.Header.Set("User-Agent", "test-agent")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		FailIfNotEqual(t, "generated", w.Header().Get("X-Request-Id"))
+
+		const expect = `{"severity":"INFO","message":"handler","requestId":"generated"}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"access log","requestId":"generated","method":"POST","path":"/path","status":201,"bytes":5,"latency":0,"remoteAddr":"192.0.2.1:1234","userAgent":"test-agent"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(Clock)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseCallerField(false), WithClock(NewFakeClock(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), 250*time.Millisecond))))
+		middleware, err := NewHTTPMiddleware(l, WithHTTPMiddlewareRequestIDFunc(func() string { return "generated" }))
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// NOTE: start, end and timestamp are advanced by 250ms each.
+		const expect = `{"timestamp":"2023-01-02T03:04:05.5Z","severity":"INFO","message":"access log","requestId":"generated","method":"GET","path":"/","status":200,"bytes":0,"latency":250,"remoteAddr":"192.0.2.1:1234","userAgent":""}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(PropagateRequestID)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		middleware, err := NewHTTPMiddleware(l,
+			WithHTTPMiddlewareRequestIDHeader("X-Trace-Id"),
+			WithHTTPMiddlewareRequestIDFieldKey("traceId"),
+			WithHTTPMiddlewareAccessLogMessage("request"),
+		)
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "not found", http.StatusNotFound)
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-Trace-Id", "propagated")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		FailIfNotEqual(t, "propagated", w.Header().Get("X-Trace-Id"))
+
+		expect := regexp.MustCompile(`^{"severity":"WARNING","message":"request","traceId":"propagated","method":"GET","path":"/","status":404,"bytes":10,"latency":[0-9]+,"remoteAddr":"192.0.2.1:1234","userAgent":""}` + defaultLineSeparator + `$`)
+		actual := buf.String()
+		FailIfNotRegexpMatchString(t, expect, actual)
+	})
+
+	t.Run("success(SeverityFunc,Flush)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		middleware, err := NewHTTPMiddleware(l, WithHTTPMiddlewareSeverityFunc(func(int) Severity { return DEBUG }))
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.(http.Flusher).Flush() // nolint: forcetypeassert
+			_, ok := w.(http.Hijacker)
+			FailIfNotEqual(t, false, ok)
+			FailIfNotEqual(t, true, w.(interface{ Unwrap() http.ResponseWriter }).Unwrap() != nil) // nolint: forcetypeassert
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		FailIfNotEqual(t, true, w.Flushed)
+		FailIfNotRegexpMatchString(t, regexp.MustCompile(`^{"severity":"DEBUG","message":"access log","requestId":"[0-9a-f]{32}",`), buf.String())
+	})
+
+	t.Run("success(Caller)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false)))
+		middleware, err := NewHTTPMiddleware(l, WithHTTPMiddlewareRequestIDFunc(func() string { return "generated" }))
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		FailIfNotRegexpMatchString(t, regexp.MustCompile(`^{"severity":"INFO","message":"access log","requestId":"generated",`), buf.String())
+	})
+
+	t.Run("success(Panic)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		middleware, err := NewHTTPMiddleware(l, WithHTTPMiddlewareRequestIDFunc(func() string { return "generated" }))
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/written" {
+				w.WriteHeader(http.StatusAccepted)
+			}
+
+			panic(http.ErrAbortHandler)
+		}))
+
+		for _, path := range []string{"/", "/written"} {
+			func() {
+				defer func() { FailIfNotEqual(t, http.ErrAbortHandler, recover()) }()
+
+				h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+			}()
+		}
+
+		expect := regexp.MustCompile(`^{"severity":"ERROR","message":"access log","requestId":"generated","method":"GET","path":"/","status":500,[^\n]+` + defaultLineSeparator +
+			`{"severity":"INFO","message":"access log","requestId":"generated","method":"GET","path":"/written","status":202,[^\n]+` + defaultLineSeparator + `$`)
+		FailIfNotRegexpMatchString(t, expect, buf.String())
+	})
+
+	t.Run("success(InvalidRequestID)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))
+		middleware, err := NewHTTPMiddleware(l, WithHTTPMiddlewareRequestIDFunc(func() string { return "generated" }))
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusContinue)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		for _, requestID := range []string{"forged\n{\"severity\":\"ERROR\"}", strings.Repeat("a", requestIDMaxLength+1), "valid-ID_1.2:3"} {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("X-Request-Id", requestID)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+		}
+
+		expect := regexp.MustCompile(`^{"severity":"INFO","message":"access log","requestId":"generated","method":"GET","path":"/","status":204,[^\n]+` + defaultLineSeparator +
+			`{"severity":"INFO","message":"access log","requestId":"generated",[^\n]+` + defaultLineSeparator +
+			`{"severity":"INFO","message":"access log","requestId":"valid-ID_1.2:3",[^\n]+` + defaultLineSeparator + `$`)
+		FailIfNotRegexpMatchString(t, expect, buf.String())
+	})
+
+	t.Run("success(Hijack)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(bytes.NewBuffer(nil)))
+		middleware, err := NewHTTPMiddleware(l)
+		FailIfNotErrorIs(t, nil, err)
+
+		h := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, flusher := w.(http.Flusher)
+			FailIfNotEqual(t, true, flusher)
+			_, _, err := w.(http.Hijacker).Hijack() // nolint: forcetypeassert, dogsled
+			FailIfNotErrorIs(t, errForTest, err)
+		}))
+
+		h.ServeHTTP(testHijackResponseWriter{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+
+	t.Run("error(Option)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(bytes.NewBuffer(nil)))
+
+		_, err := NewHTTPMiddleware(l, WithHTTPMiddlewareRequestIDHeader(""))
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+
+		_, err = NewHTTPMiddleware(l, WithHTTPMiddlewareLatencyUnit(0))
+		FailIfNotErrorIs(t, ErrInvalidDurationUnit, err)
+
+		_, err = NewHTTPMiddleware(l, WithHTTPMiddlewareRequestIDFunc(nil))
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+
+		_, err = NewHTTPMiddleware(l, WithHTTPMiddlewareSeverityFunc(nil))
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+	})
+}
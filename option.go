@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"time"
 )
 
 // Option is a struct that handles the `*rec.Config` used when new `*rec.Logger`.
@@ -92,6 +93,19 @@ func WithSeverityThreshold(severity Severity) Option {
 	}
 }
 
+// WithAtomicSeverityThreshold returns `rec.Option` for setting `config.AtomicSeverityThreshold`.
+// `*rec.Logger` copied by Copy, With, Renew, etc. shares the `*rec.AtomicSeverity`.
+func WithAtomicSeverityThreshold(severity *AtomicSeverity) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.AtomicSeverityThreshold = severity
+
+			return nil
+		},
+	}
+}
+
 // WithUseUppercaseSeverity returns `rec.Option` for setting `config.WithUseUppercaseSeverity`.
 func WithUseUppercaseSeverity(use bool) Option {
 	return Option{
@@ -244,3 +258,203 @@ func WithLineSeparator(separator string) Option {
 		},
 	}
 }
+
+// WithFormat returns `rec.Option` for setting `config.Format`.
+func WithFormat(format Format) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.Format = format
+
+			return nil
+		},
+	}
+}
+
+// WithUseColor returns `rec.Option` for setting `config.UseColor`.
+func WithUseColor(use bool) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.UseColor = use
+
+			return nil
+		},
+	}
+}
+
+// WithAsync returns `rec.Option` for setting `config.AsyncQueueSize` and `config.AsyncPolicy`.
+func WithAsync(queueSize int, policy AsyncPolicy) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			if queueSize <= 0 {
+				return fmt.Errorf("queueSize=%d: %w", queueSize, ErrInvalidQueueSize)
+			}
+
+			config.AsyncQueueSize = queueSize
+			config.AsyncPolicy = policy
+
+			return nil
+		},
+	}
+}
+
+// WithSampling returns `rec.Option` for setting `config.SamplingTick`, `config.SamplingFirst` and `config.SamplingThereafter`.
+// The first `first` log entries per severity and message per tick are written, and then every `thereafter`th log entry is written.
+func WithSampling(tick time.Duration, first, thereafter int) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			if tick <= 0 || first < 0 || thereafter < 0 {
+				return fmt.Errorf("tick=%s, first=%d, thereafter=%d: %w", tick, first, thereafter, ErrInvalidSampling)
+			}
+
+			config.SamplingTick = tick
+			config.SamplingFirst = first
+			config.SamplingThereafter = thereafter
+
+			return nil
+		},
+	}
+}
+
+// WithTraceExtractor returns `rec.Option` for setting `config.TraceExtractor`.
+func WithTraceExtractor(extractor TraceExtractor) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.TraceExtractor = extractor
+
+			return nil
+		},
+	}
+}
+
+// WithTraceFieldKeys returns `rec.Option` for setting `config.TraceIDFieldKey`, `config.SpanIDFieldKey` and `config.TraceSampledFieldKey`.
+func WithTraceFieldKeys(traceIDKey, spanIDKey, sampledKey string) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.TraceIDFieldKey = traceIDKey
+			config.SpanIDFieldKey = spanIDKey
+			config.TraceSampledFieldKey = sampledKey
+
+			return nil
+		},
+	}
+}
+
+// WithCloudLoggingTraceFieldKeys returns `rec.Option` for setting the trace fields for Google Cloud Logging.
+//
+// cf. https://cloud.google.com/logging/docs/structured-logging#special-payload-fields
+func WithCloudLoggingTraceFieldKeys(projectID string) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			if projectID == "" {
+				return fmt.Errorf("projectID %w", ErrIsEmpty)
+			}
+
+			config.TraceIDFieldKey = cloudLoggingTraceIDFieldKey
+			config.TraceIDFieldValuePrefix = "projects/" + projectID + "/traces/"
+			config.SpanIDFieldKey = cloudLoggingSpanIDFieldKey
+			config.TraceSampledFieldKey = cloudLoggingTraceSampledFieldKey
+
+			return nil
+		},
+	}
+}
+
+// WithCallerFieldFormat returns `rec.Option` for setting `config.CallerFieldFormat`.
+func WithCallerFieldFormat(format CallerFormat) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			switch format {
+			case CallerFormatString, CallerFormatSourceLocation, CallerFormatECS:
+			default:
+				return fmt.Errorf("format=%s: %w", format, ErrUnknownFormat)
+			}
+
+			config.CallerFieldFormat = format
+
+			return nil
+		},
+	}
+}
+
+// WithErrorFieldFormat returns `rec.Option` for setting `config.ErrorFieldFormat`.
+func WithErrorFieldFormat(format ErrorFormat) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			switch format {
+			case ErrorFormatString, ErrorFormatECS:
+			default:
+				return fmt.Errorf("format=%s: %w", format, ErrUnknownFormat)
+			}
+
+			config.ErrorFieldFormat = format
+
+			return nil
+		},
+	}
+}
+
+// WithRedactor returns `rec.Option` for setting `config.Redactor`.
+func WithRedactor(redactor *Redactor) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.Redactor = redactor
+
+			return nil
+		},
+	}
+}
+
+// WithSensitiveFieldPolicy returns `rec.Option` for setting `config.SecretFieldPolicy` and `config.PIIFieldPolicy`.
+func WithSensitiveFieldPolicy(secret SensitivePolicy, pii SensitivePolicy) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			if !secret.valid() || secret == "" {
+				return fmt.Errorf("secret=%s: %w", secret, ErrUnknownSensitivePolicy)
+			}
+
+			if !pii.valid() || pii == "" {
+				return fmt.Errorf("pii=%s: %w", pii, ErrUnknownSensitivePolicy)
+			}
+
+			config.SecretFieldPolicy = secret
+			config.PIIFieldPolicy = pii
+
+			return nil
+		},
+	}
+}
+
+// WithSensitiveFieldHashSalt returns `rec.Option` for setting `config.SensitiveFieldHashSalt`.
+func WithSensitiveFieldHashSalt(salt string) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.SensitiveFieldHashSalt = salt
+
+			return nil
+		},
+	}
+}
+
+// WithClock returns `rec.Option` for setting `config.Clock`, e.g. rec.NewFixedClock, rec.NewFakeClock.
+func WithClock(clock Clock) Option {
+	return Option{
+		name: funcName(),
+		f: func(config *Config) error {
+			config.Clock = clock
+
+			return nil
+		},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func Test_funcName(t *testing.T) {
@@ -100,6 +101,31 @@ func TestSeverityThreshold(t *testing.T) {
 	}
 }
 
+func TestAtomicSeverityThreshold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		severity *AtomicSeverity
+		expect   error
+	}{
+		{"success()", NewAtomicSeverity(INFO), nil},
+		{"success(nil)", nil, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithAtomicSeverityThreshold(tt.severity)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+			FailIfNotEqual(t, tt.severity, config.AtomicSeverityThreshold)
+		})
+	}
+}
+
 func TestUseUppercaseSeverity(t *testing.T) {
 	t.Parallel()
 
@@ -462,3 +488,235 @@ func TestLineSeparator(t *testing.T) {
 		})
 	}
 }
+
+func TestAsync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		queueSize    int
+		policy       AsyncPolicy
+		expect       error
+		expectConfig int
+	}{
+		{"success()", 1024, AsyncPolicyDropOldest, nil, 1024},
+		{"error(queueSize)", 0, AsyncPolicyDropOldest, ErrInvalidQueueSize, 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithAsync(tt.queueSize, tt.policy)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+			FailIfNotEqual(t, tt.expectConfig, config.AsyncQueueSize)
+		})
+	}
+}
+
+func TestSampling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		tick       time.Duration
+		first      int
+		thereafter int
+		expect     error
+		expectTick time.Duration
+	}{
+		{"success()", time.Second, 10, 100, nil, time.Second},
+		{"error(tick)", 0, 10, 100, ErrInvalidSampling, 0},
+		{"error(first)", time.Second, -1, 100, ErrInvalidSampling, 0},
+		{"error(thereafter)", time.Second, 10, -1, ErrInvalidSampling, 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithSampling(tt.tick, tt.first, tt.thereafter)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+			FailIfNotEqual(t, tt.expectTick, config.SamplingTick)
+		})
+	}
+}
+
+func TestTraceFieldKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(WithTraceExtractor)", func(t *testing.T) {
+		t.Parallel()
+
+		config := NewConfig()
+		FailIfNotErrorIs(t, nil, WithTraceExtractor(testTraceExtractor).f(config))
+		FailIfEqual(t, nil, config.TraceExtractor)
+	})
+
+	t.Run("success(WithTraceFieldKeys)", func(t *testing.T) {
+		t.Parallel()
+
+		config := NewConfig()
+		FailIfNotErrorIs(t, nil, WithTraceFieldKeys("traceId", "spanId", "sampled").f(config))
+		FailIfNotEqual(t, "traceId", config.TraceIDFieldKey)
+		FailIfNotEqual(t, "spanId", config.SpanIDFieldKey)
+		FailIfNotEqual(t, "sampled", config.TraceSampledFieldKey)
+	})
+
+	t.Run("error(WithCloudLoggingTraceFieldKeys)", func(t *testing.T) {
+		t.Parallel()
+
+		config := NewConfig()
+		FailIfNotErrorIs(t, ErrIsEmpty, WithCloudLoggingTraceFieldKeys("").f(config))
+		FailIfNotEqual(t, defaultTraceIDFieldKey, config.TraceIDFieldKey)
+	})
+}
+
+func TestCallerFieldFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format CallerFormat
+		expect error
+	}{
+		{"success(String)", CallerFormatString, nil},
+		{"success(SourceLocation)", CallerFormatSourceLocation, nil},
+		{"success(ECS)", CallerFormatECS, nil},
+		{"error(unknown)", "unknown", ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithCallerFieldFormat(tt.format)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestErrorFieldFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format ErrorFormat
+		expect error
+	}{
+		{"success(String)", ErrorFormatString, nil},
+		{"success(ECS)", ErrorFormatECS, nil},
+		{"error(unknown)", "unknown", ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithErrorFieldFormat(tt.format)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestSensitiveFieldPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		secret SensitivePolicy
+		pii    SensitivePolicy
+		expect error
+	}{
+		{"success()", SensitivePolicyOmit, SensitivePolicyHash, nil},
+		{"error(secret)", "unknown", SensitivePolicyMask, ErrUnknownSensitivePolicy},
+		{"error(pii)", SensitivePolicyMask, "", ErrUnknownSensitivePolicy},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithSensitiveFieldPolicy(tt.secret, tt.pii)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestSensitiveFieldHashSalt(t *testing.T) {
+	t.Parallel()
+
+	config := NewConfig()
+	option := WithSensitiveFieldHashSalt("salt")
+	FailIfNotErrorIs(t, nil, option.f(config))
+	FailIfNotEqual(t, "salt", config.SensitiveFieldHashSalt)
+}
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	clock := NewFixedClock(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	config := NewConfig()
+	option := WithClock(clock)
+	FailIfNotErrorIs(t, nil, option.f(config))
+	FailIfNotEqual(t, Clock(clock), config.Clock)
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format Format
+		expect error
+	}{
+		{"success()", FormatConsole, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithFormat(tt.format)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+			FailIfNotEqual(t, tt.format, config.Format)
+		})
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		use    bool
+		expect error
+	}{
+		{"success()", false, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig()
+			option := WithUseColor(tt.use)
+			actual := option.f(config)
+			FailIfNotErrorIs(t, tt.expect, actual)
+			FailIfNotEqual(t, tt.use, config.UseColor)
+		})
+	}
+}
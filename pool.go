@@ -26,3 +26,21 @@ var pcPool = &sync.Pool{ // nolint: gochecknoglobals
 		return &programcounter{make([]uintptr, pcPoolCap)}
 	},
 }
+
+var entryPool = &sync.Pool{ // nolint: gochecknoglobals
+	New: func() interface{} {
+		return &Entry{}
+	},
+}
+
+var objectEncoderPool = &sync.Pool{ // nolint: gochecknoglobals
+	New: func() interface{} {
+		return &ObjectEncoder{}
+	},
+}
+
+var arrayEncoderPool = &sync.Pool{ // nolint: gochecknoglobals
+	New: func() interface{} {
+		return &ArrayEncoder{}
+	},
+}
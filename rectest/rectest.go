@@ -0,0 +1,356 @@
+// Package rectest provides `*rec.Logger` that records the log entries in memory for tests.
+//
+//	logger, logs := rectest.New(t)
+//	logger.Info("created", rec.String("id", "abc"), rec.Int("status", 201))
+//
+//	if logs.FilterSeverity(rec.INFO).FilterField("status", 201).Len() != 1 {
+//		t.Errorf("entry not found: %v", logs.All())
+//	}
+package rectest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kunitsuinc/rec.go"
+)
+
+const (
+	timestampFieldKey = "timestamp"
+	severityFieldKey  = "severity"
+	callerFieldKey    = "caller"
+	messageFieldKey   = "message"
+)
+
+// ErrInvalidEntry log entry is not a JSON object.
+var ErrInvalidEntry = errors.New("invalid entry")
+
+// Field is the field of the recorded log entry.
+type Field struct {
+	Key string
+	// Value is the decoded JSON value: nil, bool, string, int64, float64, []interface{} or map[string]interface{}.
+	Value interface{}
+}
+
+// LoggedEntry is the recorded log entry.
+type LoggedEntry struct {
+	Time     time.Time
+	Severity rec.Severity
+	Caller   string
+	Message  string
+	// Fields contains the fields added by With and the fields of the log entry, in the order they were written.
+	Fields []Field
+
+	// severity is the severity as written, e.g. `INFO`.
+	severity string
+}
+
+// Field returns the value of the field, and reports whether the field exists.
+func (e LoggedEntry) Field(key string) (interface{}, bool) {
+	for _, f := range e.Fields {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// String returns the log entry like `2009-11-10T23:00:00Z INFO main_test.go:10 message key=value`.
+func (e LoggedEntry) String() string {
+	b := new(strings.Builder)
+
+	if !e.Time.IsZero() {
+		_, _ = fmt.Fprintf(b, "%s ", e.Time.Format(time.RFC3339Nano))
+	}
+
+	_, _ = fmt.Fprintf(b, "%s", e.severity)
+
+	if e.Caller != "" {
+		_, _ = fmt.Fprintf(b, " %s", e.Caller)
+	}
+
+	_, _ = fmt.Fprintf(b, " %s", e.Message)
+
+	for _, f := range e.Fields {
+		_, _ = fmt.Fprintf(b, " %s=%v", f.Key, f.Value)
+	}
+
+	return b.String()
+}
+
+// ObservedLogs is the recorded log entries. It is safe for concurrent use.
+type ObservedLogs struct {
+	mu   sync.RWMutex
+	logs []LoggedEntry
+}
+
+// Len returns the number of the log entries.
+func (o *ObservedLogs) Len() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return len(o.logs)
+}
+
+// All returns the copy of the log entries.
+func (o *ObservedLogs) All() []LoggedEntry {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	logs := make([]LoggedEntry, len(o.logs))
+	copy(logs, o.logs)
+
+	return logs
+}
+
+// TakeAll returns the log entries and removes them.
+func (o *ObservedLogs) TakeAll() []LoggedEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	logs := o.logs
+	o.logs = nil
+
+	return logs
+}
+
+// Filter returns `*rectest.ObservedLogs` that contains the log entries for which f returns true.
+func (o *ObservedLogs) Filter(f func(entry LoggedEntry) bool) *ObservedLogs {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	filtered := &ObservedLogs{}
+
+	for _, entry := range o.logs {
+		if f(entry) {
+			filtered.logs = append(filtered.logs, entry)
+		}
+	}
+
+	return filtered
+}
+
+// FilterSeverity returns `*rectest.ObservedLogs` that contains the log entries of the severity.
+func (o *ObservedLogs) FilterSeverity(severity rec.Severity) *ObservedLogs {
+	return o.Filter(func(entry LoggedEntry) bool {
+		return entry.Severity == severity
+	})
+}
+
+// FilterMessage returns `*rectest.ObservedLogs` that contains the log entries of the message.
+func (o *ObservedLogs) FilterMessage(message string) *ObservedLogs {
+	return o.Filter(func(entry LoggedEntry) bool {
+		return entry.Message == message
+	})
+}
+
+// FilterMessageSnippet returns `*rectest.ObservedLogs` that contains the log entries whose message contains the snippet.
+func (o *ObservedLogs) FilterMessageSnippet(snippet string) *ObservedLogs {
+	return o.Filter(func(entry LoggedEntry) bool {
+		return strings.Contains(entry.Message, snippet)
+	})
+}
+
+// FilterField returns `*rectest.ObservedLogs` that contains the log entries that have the field.
+// value is compared after it is encoded to JSON and decoded, so `rec.Int("status", 200)` matches `FilterField("status", 200)`.
+func (o *ObservedLogs) FilterField(key string, value interface{}) *ObservedLogs {
+	expect, err := normalize(value)
+	if err != nil {
+		return &ObservedLogs{}
+	}
+
+	return o.Filter(func(entry LoggedEntry) bool {
+		actual, ok := entry.Field(key)
+
+		return ok && reflect.DeepEqual(expect, actual)
+	})
+}
+
+// FilterFieldKey returns `*rectest.ObservedLogs` that contains the log entries that have the field of the key.
+func (o *ObservedLogs) FilterFieldKey(key string) *ObservedLogs {
+	return o.Filter(func(entry LoggedEntry) bool {
+		_, ok := entry.Field(key)
+
+		return ok
+	})
+}
+
+func (o *ObservedLogs) add(entry LoggedEntry) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.logs = append(o.logs, entry)
+}
+
+// observer is io.Writer that decodes the log entries written by `*rec.Logger` and records them.
+type observer struct {
+	logger *rec.Logger
+	logs   *ObservedLogs
+}
+
+func (w *observer) Write(p []byte) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+
+	for {
+		entry, err := w.decode(dec)
+		if errors.Is(err, io.EOF) {
+			return len(p), nil
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("(*rectest.observer).decode: %q: %w", p, err)
+		}
+
+		w.logs.add(entry)
+	}
+}
+
+// decode decodes the JSON object of the log entry, keeping the order of the fields.
+// nolint: cyclop
+func (w *observer) decode(dec *json.Decoder) (LoggedEntry, error) {
+	var entry LoggedEntry
+
+	tok, err := dec.Token()
+	if err != nil {
+		return entry, err // nolint: wrapcheck
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return entry, fmt.Errorf("token=%v: %w", tok, ErrInvalidEntry)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return entry, fmt.Errorf("(*json.Decoder).Token: %w", err)
+		}
+
+		key, _ := tok.(string)
+
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return entry, fmt.Errorf("(*json.Decoder).Decode: %w", err)
+		}
+
+		v = normalizeNumber(v)
+		s, isString := v.(string)
+
+		switch {
+		case key == timestampFieldKey && isString:
+			entry.Time, _ = time.Parse(time.RFC3339Nano, s)
+		case key == severityFieldKey && isString:
+			entry.Severity, _ = w.logger.ParseSeverity(s)
+			entry.severity = s
+		case key == callerFieldKey && isString:
+			entry.Caller = s
+		case key == messageFieldKey && isString:
+			entry.Message = s
+		default:
+			entry.Fields = append(entry.Fields, Field{Key: key, Value: v})
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return entry, fmt.Errorf("(*json.Decoder).Token: %w", err)
+	}
+
+	return entry, nil
+}
+
+// normalizeNumber converts json.Number to int64 if possible, otherwise to float64.
+func normalizeNumber(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeNumber(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = normalizeNumber(v[k])
+		}
+	}
+
+	return v
+}
+
+// normalize returns value that is encoded to JSON and decoded in the same way as the recorded fields.
+func normalize(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("(*json.Decoder).Decode: %w", err)
+	}
+
+	return normalizeNumber(v), nil
+}
+
+// New returns `*rec.Logger` that records the log entries in memory, and `*rectest.ObservedLogs` to query them.
+//
+// The format, the keys and the formats of the timestamp and the caller are overwritten after options so that the log entries can be decoded.
+// If tb is not nil, the recorded log entries are dumped by tb.Log only when the test fails.
+func New(tb testing.TB, options ...rec.Option) (*rec.Logger, *ObservedLogs) {
+	logs := &ObservedLogs{}
+	w := &observer{logs: logs}
+
+	options = append(options[:len(options):len(options)],
+		rec.WithFormat(rec.FormatJSON),
+		rec.WithTimestampFieldKey(timestampFieldKey),
+		rec.WithTimestampFieldFormat(time.RFC3339Nano),
+		rec.WithSeverityFieldKey(severityFieldKey),
+		rec.WithCallerFieldKey(callerFieldKey),
+		rec.WithCallerFieldFormat(rec.CallerFormatString),
+		rec.WithMessageFieldKey(messageFieldKey),
+	)
+
+	w.logger = rec.Must(rec.New(w, options...))
+
+	if tb != nil {
+		tb.Cleanup(func() {
+			if !tb.Failed() {
+				return
+			}
+
+			tb.Log(logs.dump())
+		})
+	}
+
+	return w.logger, logs
+}
+
+func (o *ObservedLogs) dump() string {
+	logs := o.All()
+
+	b := new(strings.Builder)
+	_, _ = fmt.Fprintf(b, "rectest: %d log entries recorded:", len(logs))
+
+	for _, entry := range logs {
+		_, _ = fmt.Fprintf(b, "\n\t%s", entry)
+	}
+
+	return b.String()
+}
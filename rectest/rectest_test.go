@@ -0,0 +1,161 @@
+// nolint: testpackage
+package rectest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kunitsuinc/rec.go"
+)
+
+func FailIfNotEqual(t *testing.T, expect interface{}, actual interface{}) {
+	t.Helper()
+
+	if !reflect.DeepEqual(expect, actual) {
+		t.Errorf("\n--- expect\n+++ actual\n-%v\n+%v\n", expect, actual)
+	}
+}
+
+// testTB records the logs and the cleanup functions instead of *testing.T.
+type testTB struct {
+	testing.TB
+	failed   bool
+	fatal    string
+	logs     []string
+	cleanups []func()
+}
+
+func (tb *testTB) Helper() {}
+
+func (tb *testTB) Fatalf(format string, args ...interface{}) {
+	tb.fatal = fmt.Sprintf(format, args...)
+}
+
+func (tb *testTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+func (tb *testTB) Failed() bool {
+	return tb.failed
+}
+
+func (tb *testTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, args[0].(string)) // nolint: forcetypeassert
+}
+
+func (tb *testTB) runCleanups() {
+	for _, f := range tb.cleanups {
+		f()
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		begin := time.Now()
+		l, logs := New(t, rec.WithFormat(rec.FormatConsole), rec.WithMessageFieldKey("msg"))
+		l.With(rec.String("requestId", "abc")).Info("created", rec.Int("status", 201), rec.Float64("ratio", 0.5), rec.Bool("ok", true), rec.Error(errors.New("test error")), rec.Group("group", rec.Int("count", 1)))
+
+		FailIfNotEqual(t, 1, logs.Len())
+		entry := logs.All()[0]
+		FailIfNotEqual(t, false, entry.Time.Before(begin.Truncate(time.Second)))
+		FailIfNotEqual(t, rec.INFO, entry.Severity)
+		FailIfNotEqual(t, true, regexp.MustCompile(`rectest_test\.go:\d+$`).MatchString(entry.Caller))
+		FailIfNotEqual(t, "created", entry.Message)
+		FailIfNotEqual(t, []Field{
+			{"requestId", "abc"},
+			{"status", int64(201)},
+			{"ratio", 0.5},
+			{"ok", true},
+			{"error", "test error"},
+			{"group", map[string]interface{}{"count": int64(1)}},
+		}, entry.Fields)
+
+		value, ok := entry.Field("status")
+		FailIfNotEqual(t, true, ok)
+		FailIfNotEqual(t, int64(201), value)
+		_, ok = entry.Field("notfound")
+		FailIfNotEqual(t, false, ok)
+	})
+
+	t.Run("success(Dump)", func(t *testing.T) {
+		t.Parallel()
+
+		tb := &testTB{}
+		l, _ := New(tb, rec.WithUseTimestampField(false), rec.WithUseCallerField(false))
+		l.Warning("test", rec.String("key", "value"))
+
+		tb.runCleanups()
+		FailIfNotEqual(t, 0, len(tb.logs))
+
+		tb.failed = true
+		tb.runCleanups()
+		FailIfNotEqual(t, []string{"rectest: 1 log entries recorded:\n\tWARNING test key=value"}, tb.logs)
+	})
+
+	t.Run("success(nil)", func(t *testing.T) {
+		t.Parallel()
+
+		l, logs := New(nil)
+		l.Debug("test")
+		FailIfNotEqual(t, 1, logs.Len())
+	})
+}
+
+func TestObservedLogs(t *testing.T) {
+	t.Parallel()
+
+	l, logs := New(t)
+	l.Info("request", rec.Int("status", 200), rec.String("path", "/"))
+	l.Info("request", rec.Int("status", 500), rec.String("path", "/error"))
+	l.Error("failed to request", rec.Int("status", 500))
+	l.Debug("debug")
+
+	FailIfNotEqual(t, 4, logs.Len())
+	FailIfNotEqual(t, 2, logs.FilterSeverity(rec.INFO).Len())
+	FailIfNotEqual(t, 2, logs.FilterMessage("request").Len())
+	FailIfNotEqual(t, 3, logs.FilterMessageSnippet("request").Len())
+	FailIfNotEqual(t, 2, logs.FilterField("status", 500).Len())
+	FailIfNotEqual(t, 0, logs.FilterField("status", "500").Len())
+	FailIfNotEqual(t, 0, logs.FilterField("status", make(chan int)).Len())
+	FailIfNotEqual(t, 3, logs.FilterFieldKey("status").Len())
+	FailIfNotEqual(t, "/error", logs.FilterSeverity(rec.INFO).FilterField("status", 500).All()[0].Fields[1].Value)
+
+	taken := logs.TakeAll()
+	FailIfNotEqual(t, 4, len(taken))
+	FailIfNotEqual(t, 0, logs.Len())
+	FailIfNotEqual(t, true, strings.HasSuffix(taken[3].String(), "DEBUG "+taken[3].Caller+" debug"))
+}
+
+func Test_observer_Write(t *testing.T) {
+	t.Parallel()
+
+	_, logs := New(nil)
+	w := &observer{logs: logs}
+
+	tests := []struct {
+		name string
+		p    string
+	}{
+		{"error(NotObject)", `"string"`},
+		{"error(Syntax)", `{"key":}`},
+		{"error(Unterminated)", `{"key":"value"`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := w.Write([]byte(tt.p))
+			FailIfNotEqual(t, true, err != nil)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package rectest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kunitsuinc/rec.go"
+)
+
+// TestWriter is io.Writer that writes each log entry by t.Log, so that the log entries are attached to the test even if the tests run in parallel.
+//
+// NOTE: the file:line that t.Log prints is always TestWriter.Write, because t.Helper cannot mark the functions in rec package.
+// The caller of the log entry is the caller field of the log entry itself, so do not disable it by rec.WithUseCallerField(false).
+type TestWriter struct {
+	mu     sync.RWMutex
+	tb     testing.TB
+	closed bool
+}
+
+// NewTestWriter returns `*rectest.TestWriter`.
+func NewTestWriter(tb testing.TB) *TestWriter {
+	return &TestWriter{tb: tb}
+}
+
+// Write writes p by t.Log without the trailing line separator.
+// After Close, p is written to os.Stderr because t.Log panics after the test has completed.
+func (w *TestWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		n, err := os.Stderr.Write(p)
+		if err != nil {
+			return n, fmt.Errorf("os.Stderr.Write: %w", err)
+		}
+
+		return n, nil
+	}
+
+	w.tb.Log(strings.TrimRight(string(p), "\r\n"))
+
+	return len(p), nil
+}
+
+// Close stops writing by t.Log.
+func (w *TestWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+
+	return nil
+}
+
+// NewLogger returns `*rec.Logger` that writes the log entries by t.Log. If options are invalid, the test fails.
+// The `*rec.Logger` is closed by t.Cleanup when the test and all its subtests complete.
+//
+//	func TestXxx(t *testing.T) {
+//		t.Parallel()
+//
+//		l := rectest.NewLogger(t, rec.WithFormat(rec.FormatConsole))
+//		l.Info("attached to TestXxx")
+//	}
+func NewLogger(tb testing.TB, options ...rec.Option) *rec.Logger {
+	tb.Helper()
+
+	w := NewTestWriter(tb)
+
+	l, err := rec.New(w, options...)
+	if err != nil {
+		tb.Fatalf("rectest.NewLogger: %v", err)
+
+		return nil
+	}
+
+	tb.Cleanup(func() {
+		// NOTE: if the io.Writer is `*rec.AsyncWriter`, the queued log entries are written before w is closed.
+		_ = l.Close()
+		_ = w.Close()
+	})
+
+	return l
+}
+
+// ReplaceDefaultLogger replaces the default logger in rec package, and rolls back it by t.Cleanup.
+// The tests that call it must not run in parallel, because the default logger is shared.
+func ReplaceDefaultLogger(tb testing.TB, l *rec.Logger) {
+	tb.Helper()
+
+	tb.Cleanup(rec.ReplaceDefaultLogger(l))
+}
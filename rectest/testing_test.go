@@ -0,0 +1,85 @@
+// nolint: testpackage
+package rectest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/kunitsuinc/rec.go"
+)
+
+func TestNewLogger(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		tb := &testTB{}
+		l := NewLogger(tb, rec.WithUseTimestampField(false), rec.WithUseCallerField(false))
+		l.Info("first")
+		l.With(rec.String("key", "value")).Warning("second")
+
+		FailIfNotEqual(t, []string{`{"severity":"INFO","message":"first"}`, `{"severity":"WARNING","message":"second","key":"value"}`}, tb.logs)
+
+		tb.runCleanups()
+		l.Info("after cleanup")
+		FailIfNotEqual(t, 2, len(tb.logs))
+	})
+
+	t.Run("success(Caller)", func(t *testing.T) {
+		t.Parallel()
+
+		tb := &testTB{}
+		l := NewLogger(tb, rec.WithUseTimestampField(false))
+		l.Info("caller")
+
+		FailIfNotEqual(t, 1, len(tb.logs))
+		if !regexp.MustCompile(`^{"severity":"INFO","caller":"rectest/testing_test.go:[0-9]+","message":"caller"}$`).MatchString(tb.logs[0]) {
+			t.Errorf("caller is not the test: %s", tb.logs[0])
+		}
+	})
+
+	t.Run("success(testing.T)", func(t *testing.T) {
+		t.Parallel()
+
+		l := NewLogger(t)
+		l.Info("attached to the test")
+	})
+
+	t.Run("success(Async)", func(t *testing.T) {
+		t.Parallel()
+
+		tb := &testTB{}
+		l := NewLogger(tb, rec.WithUseTimestampField(false), rec.WithUseCallerField(false), rec.WithAsync(8, rec.AsyncPolicyBlock))
+		l.Info("queued")
+
+		tb.runCleanups()
+		FailIfNotEqual(t, []string{`{"severity":"INFO","message":"queued"}`}, tb.logs)
+	})
+
+	t.Run("error(Option)", func(t *testing.T) {
+		t.Parallel()
+
+		tb := &testTB{}
+		l := NewLogger(tb, rec.WithFormat("unknown"))
+		FailIfNotEqual(t, (*rec.Logger)(nil), l)
+		if !regexp.MustCompile(`^rectest\.NewLogger: .*unknown format`).MatchString(tb.fatal) {
+			t.Errorf("unexpected fatal: %s", tb.fatal)
+		}
+	})
+}
+
+// nolint: paralleltest
+func TestReplaceDefaultLogger(t *testing.T) {
+	backup := rec.L()
+
+	tb := &testTB{}
+	l := NewLogger(tb, rec.WithUseTimestampField(false), rec.WithUseCallerField(false))
+	ReplaceDefaultLogger(tb, l)
+	rec.L().Info("replaced")
+
+	FailIfNotEqual(t, []string{`{"severity":"INFO","message":"replaced"}`}, tb.logs)
+
+	tb.runCleanups()
+	FailIfNotEqual(t, backup, rec.L())
+}
@@ -0,0 +1,425 @@
+package rec
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactReplacement is the default replacement of the redacted values.
+const DefaultRedactReplacement = "[REDACTED]"
+
+// The value patterns for WithRedactValuePatterns.
+var (
+	// RedactPatternJWT matches JSON Web Token.
+	RedactPatternJWT = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// RedactPatternCreditCard matches 13-19 digits credit card numbers that may be separated by spaces or hyphens.
+	RedactPatternCreditCard = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// RedactPatternEmail matches email addresses.
+	RedactPatternEmail = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// Redactor redacts the sensitive values of the fields by key and by value pattern.
+//
+// The value of the field whose key matches is replaced with `"[REDACTED]"`, including the nested keys of rec.Object, rec.Group and rec.Marshaler.
+// The substrings of the string values and the message that match the value patterns are replaced with `[REDACTED]`.
+// The fields added by With are redacted when they are added, and again when the Redactor is set by Renew.
+type Redactor struct {
+	keys            map[string]struct{}
+	foldKeys        []string
+	globs           []string
+	patterns        []*regexp.Regexp
+	replacement     string
+	jsonReplacement []byte
+}
+
+// RedactorOption is a struct that handles the `*rec.Redactor` used when new `*rec.Redactor`.
+type RedactorOption struct {
+	name string
+	f    func(*Redactor) error
+}
+
+// WithRedactKeys returns `rec.RedactorOption` for redacting the fields whose key exactly matches.
+func WithRedactKeys(keys ...string) RedactorOption {
+	return RedactorOption{
+		name: funcName(),
+		f: func(r *Redactor) error {
+			for _, key := range keys {
+				r.keys[key] = struct{}{}
+			}
+
+			return nil
+		},
+	}
+}
+
+// WithRedactKeysCaseInsensitive returns `rec.RedactorOption` for redacting the fields whose key matches case-insensitively.
+func WithRedactKeysCaseInsensitive(keys ...string) RedactorOption {
+	return RedactorOption{
+		name: funcName(),
+		f: func(r *Redactor) error {
+			r.foldKeys = append(r.foldKeys, keys...)
+
+			return nil
+		},
+	}
+}
+
+// WithRedactKeyGlobs returns `rec.RedactorOption` for redacting the fields whose key matches the glob pattern.
+// `*` matches any sequence of characters, and `?` matches any single character, e.g. `*token*`, `x-api-key?`.
+func WithRedactKeyGlobs(patterns ...string) RedactorOption {
+	return RedactorOption{
+		name: funcName(),
+		f: func(r *Redactor) error {
+			r.globs = append(r.globs, patterns...)
+
+			return nil
+		},
+	}
+}
+
+// WithRedactValuePatterns returns `rec.RedactorOption` for masking the substrings that match the patterns,
+// e.g. rec.RedactPatternJWT, rec.RedactPatternCreditCard, rec.RedactPatternEmail.
+func WithRedactValuePatterns(patterns ...*regexp.Regexp) RedactorOption {
+	return RedactorOption{
+		name: funcName(),
+		f: func(r *Redactor) error {
+			for i, pattern := range patterns {
+				if pattern == nil {
+					return fmt.Errorf("patterns[%d] %w", i, ErrIsEmpty)
+				}
+			}
+
+			r.patterns = append(r.patterns, patterns...)
+
+			return nil
+		},
+	}
+}
+
+// WithRedactReplacement returns `rec.RedactorOption` for setting the replacement of the redacted values. Default is `[REDACTED]`.
+func WithRedactReplacement(replacement string) RedactorOption {
+	return RedactorOption{
+		name: funcName(),
+		f: func(r *Redactor) error {
+			r.replacement = replacement
+
+			return nil
+		},
+	}
+}
+
+// NewRedactor returns `*rec.Redactor`. Set it by WithRedactor.
+func NewRedactor(options ...RedactorOption) (*Redactor, error) {
+	r := &Redactor{
+		keys:        make(map[string]struct{}),
+		replacement: DefaultRedactReplacement,
+	}
+
+	for _, opt := range options {
+		if err := opt.f(r); err != nil {
+			return nil, fmt.Errorf("%s: %w", opt.name, err)
+		}
+	}
+
+	r.jsonReplacement = appendJSONEscapedString(nil, r.replacement)
+
+	return r, nil
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+
+	for _, foldKey := range r.foldKeys {
+		if strings.EqualFold(key, foldKey) {
+			return true
+		}
+	}
+
+	for _, glob := range r.globs {
+		if matchGlob(glob, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchGlob reports whether s matches the pattern that `*` matches any sequence of characters and `?` matches any single character.
+func matchGlob(pattern, s string) bool {
+	var p, i, starP, starI int
+
+	starP = -1
+
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case p < len(pattern) && pattern[p] == '*':
+			starP, starI = p, i
+			p++
+		case starP >= 0:
+			starI++
+			p, i = starP+1, starI
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
+
+// maskString returns s that the substrings matching the value patterns are replaced.
+func (r *Redactor) maskString(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(s) {
+			s = pattern.ReplaceAllLiteralString(s, r.replacement)
+		}
+	}
+
+	return s
+}
+
+// redactJSONField redacts `"key":value` in dst[start:].
+func (r *Redactor) redactJSONField(dst []byte, start int) []byte {
+	if r == nil {
+		return dst
+	}
+
+	keyEnd := scanJSONString(dst, start)
+	if keyEnd < 0 || keyEnd >= len(dst) || dst[keyEnd] != ':' {
+		return dst
+	}
+
+	return r.redactValue(dst, keyEnd+1, unquoteJSONString(dst[start:keyEnd]))
+}
+
+// redactValue redacts the JSON value in dst[start:]. If key matches, the value is replaced with the replacement.
+func (r *Redactor) redactValue(dst []byte, start int, key string) []byte {
+	if r == nil || start >= len(dst) {
+		return dst
+	}
+
+	if r.matchKey(key) {
+		return append(append(append(dst[:start], '"'), r.jsonReplacement...), '"')
+	}
+
+	switch dst[start] {
+	case '"':
+		end := scanJSONString(dst, start)
+		if end < 0 {
+			return dst
+		}
+
+		return r.appendMaskedJSONString(dst[:start], dst[start:end])
+	case '{', '[':
+	default:
+		return dst
+	}
+
+	b := bufferPool.Get().(*buffer) // nolint: forcetypeassert
+	defer bufferPool.Put(b)
+
+	b.Buffer, _ = r.appendRedactedJSON(b.Buffer[:0], dst[start:])
+
+	return append(dst[:start], b.Buffer...)
+}
+
+// appendRedactedJSON appends the JSON value at the head of src with redaction, and returns the rest of src.
+// nolint: cyclop
+func (r *Redactor) appendRedactedJSON(dst, src []byte) ([]byte, []byte) {
+	dst, src = appendJSONWhitespace(dst, src)
+	if len(src) == 0 {
+		return dst, src
+	}
+
+	switch src[0] {
+	case '{':
+		dst, src = append(dst, '{'), src[1:]
+
+		for {
+			dst, src = appendJSONWhitespace(dst, src)
+			if len(src) == 0 || src[0] != '"' {
+				break
+			}
+
+			keyEnd := scanJSONString(src, 0)
+			if keyEnd < 0 {
+				return append(dst, src...), nil
+			}
+
+			key := unquoteJSONString(src[:keyEnd])
+			dst, src = append(dst, src[:keyEnd]...), src[keyEnd:]
+			dst, src = appendJSONWhitespace(dst, src)
+
+			if len(src) == 0 || src[0] != ':' {
+				return append(dst, src...), nil
+			}
+
+			dst, src = append(dst, ':'), src[1:]
+
+			if r.matchKey(key) {
+				dst = append(append(append(dst, '"'), r.jsonReplacement...), '"')
+				_, src = appendJSONWhitespace(nil, src)
+				src = src[scanJSONValue(src):]
+			} else {
+				dst, src = r.appendRedactedJSON(dst, src)
+			}
+
+			dst, src = appendJSONWhitespace(dst, src)
+			if len(src) == 0 || src[0] != ',' {
+				break
+			}
+
+			dst, src = append(dst, ','), src[1:]
+		}
+
+		if len(src) > 0 && src[0] == '}' {
+			dst, src = append(dst, '}'), src[1:]
+		}
+	case '[':
+		dst, src = append(dst, '['), src[1:]
+
+		for {
+			dst, src = appendJSONWhitespace(dst, src)
+			if len(src) == 0 || src[0] == ']' {
+				break
+			}
+
+			dst, src = r.appendRedactedJSON(dst, src)
+			dst, src = appendJSONWhitespace(dst, src)
+
+			if len(src) == 0 || src[0] != ',' {
+				break
+			}
+
+			dst, src = append(dst, ','), src[1:]
+		}
+
+		if len(src) > 0 && src[0] == ']' {
+			dst, src = append(dst, ']'), src[1:]
+		}
+	case '"':
+		end := scanJSONString(src, 0)
+		if end < 0 {
+			return append(dst, src...), nil
+		}
+
+		dst, src = r.appendMaskedJSONString(dst, src[:end]), src[end:]
+	default:
+		end := scanJSONValue(src)
+		dst, src = append(dst, src[:end]...), src[end:]
+	}
+
+	return dst, src
+}
+
+// appendMaskedJSONString appends the quoted JSON string that the substrings matching the value patterns are replaced.
+// NOTE: the patterns are matched with the unescaped string, and the result is escaped again, so that the output is valid JSON.
+// quoted may overlap dst[len(dst):], so it is unquoted before dst is appended.
+func (r *Redactor) appendMaskedJSONString(dst, quoted []byte) []byte {
+	s := unquoteJSONString(quoted)
+
+	masked := r.maskString(s)
+	if masked == s {
+		return append(dst, quoted...)
+	}
+
+	return append(appendJSONEscapedString(append(dst, '"'), masked), '"')
+}
+
+func appendJSONWhitespace(dst, src []byte) ([]byte, []byte) {
+	i := 0
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t' || src[i] == '\n' || src[i] == '\r') {
+		i++
+	}
+
+	return append(dst, src[:i]...), src[i:]
+}
+
+// scanJSONString returns the index after the closing quote of the JSON string that starts at b[start], or -1.
+func scanJSONString(b []byte, start int) int {
+	if start >= len(b) || b[start] != '"' {
+		return -1
+	}
+
+	for i := start + 1; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// scanJSONValue returns the length of the JSON value at the head of b.
+func scanJSONValue(b []byte) int {
+	depth := 0
+
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case '"':
+			end := scanJSONString(b, i)
+			if end < 0 {
+				return len(b)
+			}
+
+			if depth == 0 {
+				return end
+			}
+
+			i = end - 1
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return i
+			}
+
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ',', ' ', '\t', '\n', '\r':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(b)
+}
+
+// unquoteJSONString returns the string of the quoted JSON string.
+func unquoteJSONString(quoted []byte) string {
+	if len(quoted) < 2 { // nolint: gomnd
+		return ""
+	}
+
+	inner := quoted[1 : len(quoted)-1]
+	for _, c := range inner {
+		if c == '\\' {
+			var s string
+			_ = json.Unmarshal(quoted, &s)
+
+			return s
+		}
+	}
+
+	return string(inner)
+}
@@ -0,0 +1,534 @@
+package rec
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	rotatingFileBackupTimeFormat = "2006-01-02T15-04-05.000"
+	rotatingFileCompressSuffix   = ".gz"
+	defaultRotatingFilePerm      = 0o600
+)
+
+// RotatingFileWriter is an io.Writer that writes to the file and rotates it by size and/or time interval.
+//
+// The rotated file is renamed to `name-2006-01-02T15-04-05.000.ext` in the same directory.
+// If the file of the same name already exists, e.g. rotated twice in the same millisecond, the sequence number is added like `name-2006-01-02T15-04-05.000-1.ext`.
+type RotatingFileWriter struct {
+	mu     sync.Mutex
+	closed bool
+
+	filename   string
+	perm       fs.FileMode
+	maxSize    int64
+	interval   time.Duration
+	maxBackups int
+	maxAge     time.Duration
+	compress   bool
+	sighup     bool
+
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+
+	sighupCh chan os.Signal
+	sighupWg sync.WaitGroup
+	millWg   sync.WaitGroup
+	millMu   sync.Mutex
+
+	now func() time.Time
+}
+
+// RotatingFileOption is a struct that handles the `*rec.RotatingFileWriter` used when new `*rec.RotatingFileWriter`.
+type RotatingFileOption struct {
+	name string
+	f    func(*RotatingFileWriter) error
+}
+
+// WithRotatingFileMaxSize returns `rec.RotatingFileOption` for setting the maximum size in bytes of the file before it gets rotated.
+func WithRotatingFileMaxSize(maxSize int64) RotatingFileOption {
+	return RotatingFileOption{
+		name: funcName(),
+		f: func(w *RotatingFileWriter) error {
+			w.maxSize = maxSize
+
+			return nil
+		},
+	}
+}
+
+// WithRotatingFileInterval returns `rec.RotatingFileOption` for setting the time interval to rotate the file.
+func WithRotatingFileInterval(interval time.Duration) RotatingFileOption {
+	return RotatingFileOption{
+		name: funcName(),
+		f: func(w *RotatingFileWriter) error {
+			w.interval = interval
+
+			return nil
+		},
+	}
+}
+
+// WithRotatingFileMaxBackups returns `rec.RotatingFileOption` for setting the maximum number of rotated files to retain.
+// If 0, all rotated files are retained.
+func WithRotatingFileMaxBackups(maxBackups int) RotatingFileOption {
+	return RotatingFileOption{
+		name: funcName(),
+		f: func(w *RotatingFileWriter) error {
+			w.maxBackups = maxBackups
+
+			return nil
+		},
+	}
+}
+
+// WithRotatingFileMaxAge returns `rec.RotatingFileOption` for setting the maximum age of rotated files to retain.
+// If 0, rotated files are not removed by age.
+func WithRotatingFileMaxAge(maxAge time.Duration) RotatingFileOption {
+	return RotatingFileOption{
+		name: funcName(),
+		f: func(w *RotatingFileWriter) error {
+			w.maxAge = maxAge
+
+			return nil
+		},
+	}
+}
+
+// WithRotatingFileCompress returns `rec.RotatingFileOption` for setting whether the rotated files are compressed with gzip.
+func WithRotatingFileCompress(compress bool) RotatingFileOption {
+	return RotatingFileOption{
+		name: funcName(),
+		f: func(w *RotatingFileWriter) error {
+			w.compress = compress
+
+			return nil
+		},
+	}
+}
+
+// WithRotatingFilePerm returns `rec.RotatingFileOption` for setting the permission of the file.
+func WithRotatingFilePerm(perm fs.FileMode) RotatingFileOption {
+	return RotatingFileOption{
+		name: funcName(),
+		f: func(w *RotatingFileWriter) error {
+			w.perm = perm
+
+			return nil
+		},
+	}
+}
+
+// WithRotatingFileReopenOnSIGHUP returns `rec.RotatingFileOption` for setting whether the file is reopened when the process receives SIGHUP.
+// This is useful when the file is rotated by external tools such as logrotate.
+func WithRotatingFileReopenOnSIGHUP(reopen bool) RotatingFileOption {
+	return RotatingFileOption{
+		name: funcName(),
+		f: func(w *RotatingFileWriter) error {
+			w.sighup = reopen
+
+			return nil
+		},
+	}
+}
+
+// NewRotatingFileWriter opens the file and returns `*rec.RotatingFileWriter`.
+func NewRotatingFileWriter(filename string, options ...RotatingFileOption) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{
+		filename: filename,
+		perm:     defaultRotatingFilePerm,
+		now:      time.Now,
+	}
+
+	for _, opt := range options {
+		if err := opt.f(w); err != nil {
+			return nil, fmt.Errorf("%s: %w", opt.name, err)
+		}
+	}
+
+	if err := w.open(); err != nil {
+		return nil, fmt.Errorf("(*rec.RotatingFileWriter).open: %w", err)
+	}
+
+	if w.sighup {
+		w.sighupCh = make(chan os.Signal, 1)
+		signal.Notify(w.sighupCh, syscall.SIGHUP)
+		w.sighupWg.Add(1)
+
+		go func() {
+			defer w.sighupWg.Done()
+
+			for range w.sighupCh {
+				if err := w.Reopen(); err != nil {
+					err = fmt.Errorf("(*rec.RotatingFileWriter).Reopen: %w", err)
+					defaultLogger.write(ERROR, err.Error(), Error(err))
+				}
+			}
+		}()
+	}
+
+	return w, nil
+}
+
+func (w *RotatingFileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.filename), 0o755); err != nil { // nolint: gomnd
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	file, err := os.OpenFile(w.filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, w.perm)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("(*os.File).Stat: %w", err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+
+	if w.interval > 0 {
+		w.nextRotation = nextRotationTime(w.now(), w.interval)
+	}
+
+	return nil
+}
+
+// nextRotationTime returns the next time aligned to the interval in the location of now, e.g. the midnight of the local time for 24h.
+// NOTE: time.Time.Truncate aligns to the zero time in UTC, so now is shifted by the offset of the zone before truncating.
+func nextRotationTime(now time.Time, interval time.Duration) time.Time {
+	_, offset := now.Zone()
+	shift := time.Duration(offset) * time.Second
+
+	return now.Add(shift).Truncate(interval).Add(interval).Add(-shift)
+}
+
+// Write writes p to the file. If p exceeds the maximum size or the time interval has elapsed, Write rotates the file before writing.
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, fmt.Errorf("(*rec.RotatingFileWriter).Write: %w", os.ErrClosed)
+	}
+
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, fmt.Errorf("(*rec.RotatingFileWriter).rotate: %w", err)
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	if err != nil {
+		return n, fmt.Errorf("(*os.File).Write: %w", err)
+	}
+
+	return n, nil
+}
+
+func (w *RotatingFileWriter) shouldRotate(n int) bool {
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(n) > w.maxSize {
+		return true
+	}
+
+	if w.interval > 0 && !w.now().Before(w.nextRotation) {
+		return true
+	}
+
+	return false
+}
+
+// Rotate rotates the file immediately.
+func (w *RotatingFileWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("(*rec.RotatingFileWriter).Rotate: %w", os.ErrClosed)
+	}
+
+	return w.rotate()
+}
+
+func (w *RotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("(*os.File).Close: %w", err)
+	}
+
+	if err := os.Rename(w.filename, w.backupName(w.now())); err != nil {
+		// NOTE: reopen the file in append mode, so that the following writes do not fail with the closed file.
+		if openErr := w.open(); openErr != nil {
+			return fmt.Errorf("os.Rename: %w: (*rec.RotatingFileWriter).open: %v", err, openErr) // nolint: errorlint
+		}
+
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+
+	if err := w.open(); err != nil {
+		return fmt.Errorf("(*rec.RotatingFileWriter).open: %w", err)
+	}
+
+	w.millWg.Add(1)
+
+	go func() {
+		defer w.millWg.Done()
+
+		if err := w.mill(); err != nil {
+			err = fmt.Errorf("(*rec.RotatingFileWriter).mill: %w", err)
+			defaultLogger.write(ERROR, err.Error(), Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// Reopen closes the file and opens the file again without rotation.
+func (w *RotatingFileWriter) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("(*rec.RotatingFileWriter).Reopen: %w", os.ErrClosed)
+	}
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("(*os.File).Close: %w", err)
+	}
+
+	if err := w.open(); err != nil {
+		return fmt.Errorf("(*rec.RotatingFileWriter).open: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the file and waits for compressing and removing rotated files.
+func (w *RotatingFileWriter) Close() error {
+	w.mu.Lock()
+
+	if w.closed {
+		w.mu.Unlock()
+
+		return nil
+	}
+
+	w.closed = true
+	err := w.file.Close()
+	w.mu.Unlock()
+
+	if w.sighupCh != nil {
+		signal.Stop(w.sighupCh)
+		close(w.sighupCh)
+		w.sighupWg.Wait()
+	}
+
+	w.millWg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("(*os.File).Close: %w", err)
+	}
+
+	return nil
+}
+
+func (w *RotatingFileWriter) backupPrefixAndExt() (prefix, ext string) {
+	name := filepath.Base(w.filename)
+	ext = filepath.Ext(name)
+
+	return strings.TrimSuffix(name, ext) + "-", ext
+}
+
+// backupName returns the name of the rotated file that does not exist, including the compressed one.
+func (w *RotatingFileWriter) backupName(t time.Time) string {
+	prefix, ext := w.backupPrefixAndExt()
+	base := filepath.Join(filepath.Dir(w.filename), prefix+t.Format(rotatingFileBackupTimeFormat))
+
+	for seq := 0; ; seq++ {
+		name := base + ext
+		if seq > 0 {
+			name = base + "-" + strconv.Itoa(seq) + ext
+		}
+
+		if !fileExists(name) && !fileExists(name+rotatingFileCompressSuffix) {
+			return name
+		}
+	}
+}
+
+func fileExists(name string) bool {
+	_, err := os.Lstat(name)
+
+	return !os.IsNotExist(err)
+}
+
+type rotatingFileBackup struct {
+	path      string
+	timestamp time.Time
+	seq       int
+}
+
+// mill compresses the rotated files and removes the rotated files that exceed maxBackups or maxAge.
+// nolint: cyclop
+func (w *RotatingFileWriter) mill() error {
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	backups, err := w.backups()
+	if err != nil {
+		return fmt.Errorf("(*rec.RotatingFileWriter).backups: %w", err)
+	}
+
+	var remove []rotatingFileBackup
+
+	if w.maxBackups > 0 && len(backups) > w.maxBackups {
+		remove = append(remove, backups[w.maxBackups:]...)
+		backups = backups[:w.maxBackups]
+	}
+
+	if w.maxAge > 0 {
+		cutoff := w.now().Add(-w.maxAge)
+		retained := backups[:0]
+
+		for _, backup := range backups {
+			if backup.timestamp.Before(cutoff) {
+				remove = append(remove, backup)
+
+				continue
+			}
+
+			retained = append(retained, backup)
+		}
+
+		backups = retained
+	}
+
+	for _, backup := range remove {
+		if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("os.Remove: %w", err)
+		}
+	}
+
+	if w.compress {
+		for _, backup := range backups {
+			if strings.HasSuffix(backup.path, rotatingFileCompressSuffix) {
+				continue
+			}
+
+			if err := compressFile(backup.path, w.perm); err != nil {
+				return fmt.Errorf("compressFile: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backups returns the rotated files sorted by newest first.
+func (w *RotatingFileWriter) backups() ([]rotatingFileBackup, error) {
+	dir := filepath.Dir(w.filename)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadDir: %w", err)
+	}
+
+	prefix, ext := w.backupPrefixAndExt()
+	backups := make([]rotatingFileBackup, 0, len(entries))
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), rotatingFileCompressSuffix), ext)
+		if len(timestamp) < len(rotatingFileBackupTimeFormat) {
+			continue
+		}
+
+		t, err := time.ParseInLocation(rotatingFileBackupTimeFormat, timestamp[:len(rotatingFileBackupTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+
+		var seq int
+		if suffix := timestamp[len(rotatingFileBackupTimeFormat):]; suffix != "" {
+			if seq, err = strconv.Atoi(strings.TrimPrefix(suffix, "-")); err != nil || !strings.HasPrefix(suffix, "-") || seq <= 0 {
+				continue
+			}
+		}
+
+		backups = append(backups, rotatingFileBackup{path: filepath.Join(dir, name), timestamp: t, seq: seq})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].timestamp.Equal(backups[j].timestamp) {
+			return backups[i].seq > backups[j].seq
+		}
+
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+
+	return backups, nil
+}
+
+func compressFile(path string, perm fs.FileMode) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+rotatingFileCompressSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+
+	// NOTE: remove the partially compressed file, otherwise it is retained as a backup and the file is never compressed again.
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path + rotatingFileCompressSuffix)
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+
+	if _, err := io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+
+		return fmt.Errorf("io.Copy: %w", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		_ = dst.Close()
+
+		return fmt.Errorf("(*gzip.Writer).Close: %w", err)
+	}
+
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("(*os.File).Close: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("os.Remove: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+// nolint: testpackage
+package rec
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func readDirNames(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("os.ReadDir: %v", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func readFileString(t *testing.T, path string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+
+	return string(b)
+}
+
+func newTestClock(start time.Time) func() time.Time {
+	now := start
+
+	return func() time.Time {
+		now = now.Add(time.Second)
+
+		return now
+	}
+}
+
+func TestNewRotatingFileWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(WithLogger)", func(t *testing.T) {
+		t.Parallel()
+
+		filename := filepath.Join(t.TempDir(), "sub", "test.log")
+		w, err := NewRotatingFileWriter(filename)
+		FailIfNotErrorIs(t, nil, err)
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+		l.Info("test")
+		FailIfNotErrorIs(t, nil, l.Close())
+
+		const expect = `{"severity":"INFO","message":"test"}` + defaultLineSeparator
+		actual := readFileString(t, filename)
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("failure(option)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewRotatingFileWriter(filepath.Join(t.TempDir(), "test.log"), RotatingFileOption{name: "test", f: func(*RotatingFileWriter) error { return errForTest }})
+		FailIfNotErrorIs(t, errForTest, err)
+	})
+
+	t.Run("failure(open)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewRotatingFileWriter(t.TempDir())
+		FailIfErrorIs(t, nil, err)
+	})
+}
+
+func TestRotatingFileWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(MaxSize)", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		w, err := NewRotatingFileWriter(filepath.Join(dir, "test.log"), WithRotatingFileMaxSize(10), WithRotatingFilePerm(0o644))
+		FailIfNotErrorIs(t, nil, err)
+		w.now = newTestClock(time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local))
+
+		for _, s := range []string{"12345\n", "67890\n", "abcdefghijklmn\n", "x\n"} {
+			_, err := w.Write([]byte(s))
+			FailIfNotErrorIs(t, nil, err)
+		}
+		FailIfNotErrorIs(t, nil, w.Close())
+
+		FailIfNotEqual(t, "[test-2021-01-01T00-00-01.000.log test-2021-01-01T00-00-02.000.log test-2021-01-01T00-00-03.000.log test.log]", "["+strings.Join(readDirNames(t, dir), " ")+"]")
+		FailIfNotEqual(t, "12345\n", readFileString(t, filepath.Join(dir, "test-2021-01-01T00-00-01.000.log")))
+		FailIfNotEqual(t, "67890\n", readFileString(t, filepath.Join(dir, "test-2021-01-01T00-00-02.000.log")))
+		FailIfNotEqual(t, "abcdefghijklmn\n", readFileString(t, filepath.Join(dir, "test-2021-01-01T00-00-03.000.log")))
+		FailIfNotEqual(t, "x\n", readFileString(t, filepath.Join(dir, "test.log")))
+	})
+
+	t.Run("success(SameMillisecond)", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		w, err := NewRotatingFileWriter(filepath.Join(dir, "test.log"), WithRotatingFileMaxSize(10))
+		FailIfNotErrorIs(t, nil, err)
+		w.now = func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local) }
+
+		for i := 0; i < 50; i++ {
+			_, err := w.Write([]byte(strings.Repeat("x", 17) + "\n"))
+			FailIfNotErrorIs(t, nil, err)
+		}
+		FailIfNotErrorIs(t, nil, w.Close())
+
+		var total int
+		for _, name := range readDirNames(t, dir) {
+			total += len(readFileString(t, filepath.Join(dir, name)))
+		}
+		FailIfNotEqual(t, 50*18, total)
+
+		backups, err := w.backups()
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotEqual(t, filepath.Join(dir, "test-2021-01-01T00-00-00.000-48.log"), backups[0].path)
+		FailIfNotEqual(t, filepath.Join(dir, "test-2021-01-01T00-00-00.000.log"), backups[len(backups)-1].path)
+	})
+
+	t.Run("success(Interval)", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+		w := &RotatingFileWriter{filename: filepath.Join(dir, "test.log"), perm: defaultRotatingFilePerm, interval: time.Hour, now: func() time.Time { return now }}
+		FailIfNotErrorIs(t, nil, w.open())
+
+		_, err := w.Write([]byte("a\n"))
+		FailIfNotErrorIs(t, nil, err)
+		now = now.Add(59 * time.Minute)
+		_, err = w.Write([]byte("b\n"))
+		FailIfNotErrorIs(t, nil, err)
+		now = now.Add(time.Minute)
+		_, err = w.Write([]byte("c\n"))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotErrorIs(t, nil, w.Close())
+
+		FailIfNotEqual(t, "[test-2021-01-01T01-00-00.000.log test.log]", "["+strings.Join(readDirNames(t, dir), " ")+"]")
+		FailIfNotEqual(t, "a\nb\n", readFileString(t, filepath.Join(dir, "test-2021-01-01T01-00-00.000.log")))
+		FailIfNotEqual(t, "c\n", readFileString(t, filepath.Join(dir, "test.log")))
+	})
+
+	t.Run("success(MaxBackups,Compress)", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		w, err := NewRotatingFileWriter(filepath.Join(dir, "test.log"), WithRotatingFileMaxSize(1), WithRotatingFileMaxBackups(2), WithRotatingFileCompress(true))
+		FailIfNotErrorIs(t, nil, err)
+		w.now = newTestClock(time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local))
+
+		for _, s := range []string{"1", "2", "3", "4"} {
+			_, err := w.Write([]byte(s))
+			FailIfNotErrorIs(t, nil, err)
+			w.millWg.Wait()
+		}
+		FailIfNotErrorIs(t, nil, w.Close())
+
+		FailIfNotEqual(t, "[test-2021-01-01T00-00-02.000.log.gz test-2021-01-01T00-00-03.000.log.gz test.log]", "["+strings.Join(readDirNames(t, dir), " ")+"]")
+
+		f, err := os.Open(filepath.Join(dir, "test-2021-01-01T00-00-03.000.log.gz"))
+		FailIfNotErrorIs(t, nil, err)
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		FailIfNotErrorIs(t, nil, err)
+		b, err := io.ReadAll(gz)
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotEqual(t, "3", string(b))
+	})
+
+	t.Run("success(MaxAge)", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+		w, err := NewRotatingFileWriter(filepath.Join(dir, "test.log"), WithRotatingFileMaxAge(time.Hour))
+		FailIfNotErrorIs(t, nil, err)
+		w.now = func() time.Time { return now }
+
+		FailIfNotErrorIs(t, nil, w.Rotate())
+		w.millWg.Wait()
+		now = now.Add(2 * time.Hour)
+		FailIfNotErrorIs(t, nil, w.Rotate())
+		FailIfNotErrorIs(t, nil, w.Close())
+
+		FailIfNotEqual(t, "[test-2021-01-01T02-00-00.000.log test.log]", "["+strings.Join(readDirNames(t, dir), " ")+"]")
+	})
+
+	t.Run("failure(Closed)", func(t *testing.T) {
+		t.Parallel()
+
+		w, err := NewRotatingFileWriter(filepath.Join(t.TempDir(), "test.log"))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotErrorIs(t, nil, w.Close())
+		FailIfNotErrorIs(t, nil, w.Close())
+
+		_, err = w.Write([]byte("test"))
+		FailIfNotErrorIs(t, os.ErrClosed, err)
+		FailIfNotErrorIs(t, os.ErrClosed, w.Rotate())
+		FailIfNotErrorIs(t, os.ErrClosed, w.Reopen())
+	})
+
+	t.Run("failure(rotate)", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		w, err := NewRotatingFileWriter(filepath.Join(dir, "test.log"), WithRotatingFileMaxSize(1))
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+
+		_, err = w.Write([]byte("1"))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotErrorIs(t, nil, os.Remove(filepath.Join(dir, "test.log")))
+
+		_, err = w.Write([]byte("2"))
+		FailIfNotErrorIs(t, os.ErrNotExist, err)
+
+		// NOTE: the file is reopened after the rotation fails.
+		_, err = w.Write([]byte("3"))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotEqual(t, "3", readFileString(t, filepath.Join(dir, "test.log")))
+	})
+}
+
+func Test_nextRotationTime(t *testing.T) {
+	t.Parallel()
+
+	jst := time.FixedZone("JST", 9*60*60)
+
+	FailIfNotEqual(t, time.Date(2021, 1, 2, 0, 0, 0, 0, jst), nextRotationTime(time.Date(2021, 1, 1, 8, 30, 0, 0, jst), 24*time.Hour))
+	FailIfNotEqual(t, time.Date(2021, 1, 2, 0, 0, 0, 0, jst), nextRotationTime(time.Date(2021, 1, 1, 9, 30, 0, 0, jst), 24*time.Hour))
+	FailIfNotEqual(t, time.Date(2021, 1, 1, 10, 0, 0, 0, jst), nextRotationTime(time.Date(2021, 1, 1, 9, 30, 0, 0, jst), time.Hour))
+}
+
+func Test_compressFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failure(io.Copy)", func(t *testing.T) {
+		t.Parallel()
+
+		// NOTE: reading a directory fails after the compressed file is created.
+		dir := filepath.Join(t.TempDir(), "test.log")
+		FailIfNotErrorIs(t, nil, os.Mkdir(dir, 0o755))
+
+		FailIfNotErrorIs(t, syscall.EISDIR, compressFile(dir, defaultRotatingFilePerm))
+		FailIfNotEqual(t, false, fileExists(dir+rotatingFileCompressSuffix))
+	})
+}
+
+func TestRotatingFileWriter_Reopen(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		filename := filepath.Join(dir, "test.log")
+		w, err := NewRotatingFileWriter(filename)
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+
+		_, err = w.Write([]byte("a\n"))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotErrorIs(t, nil, os.Rename(filename, filepath.Join(dir, "test.log.1")))
+		FailIfNotErrorIs(t, nil, w.Reopen())
+		_, err = w.Write([]byte("b\n"))
+		FailIfNotErrorIs(t, nil, err)
+
+		FailIfNotEqual(t, "a\n", readFileString(t, filepath.Join(dir, "test.log.1")))
+		FailIfNotEqual(t, "b\n", readFileString(t, filename))
+	})
+}
+
+// nolint: paralleltest
+func TestWithRotatingFileReopenOnSIGHUP(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "test.log")
+	w, err := NewRotatingFileWriter(filename, WithRotatingFileReopenOnSIGHUP(true))
+	FailIfNotErrorIs(t, nil, err)
+	defer w.Close()
+
+	FailIfNotErrorIs(t, nil, os.Rename(filename, filepath.Join(dir, "test.log.1")))
+	p, err := os.FindProcess(os.Getpid())
+	FailIfNotErrorIs(t, nil, err)
+	FailIfNotErrorIs(t, nil, p.Signal(syscall.SIGHUP))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(filename); err == nil {
+			break
+		} else if !errors.Is(err, os.ErrNotExist) || time.Now().After(deadline) {
+			t.Fatalf("file is not reopened: %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
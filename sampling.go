@@ -0,0 +1,100 @@
+package rec
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	samplingCounters = 4096
+
+	// cf. https://en.wikipedia.org/wiki/Fowler%E2%80%93Noll%E2%80%93Vo_hash_function
+	fnv32aOffset = 2166136261
+	fnv32aPrime  = 16777619
+)
+
+// samplingCounter counts the log entries per tick. It is updated without lock.
+type samplingCounter struct {
+	resetAt int64
+	count   uint64
+}
+
+func (c *samplingCounter) incr(now time.Time, tick time.Duration) uint64 {
+	nanos := now.UnixNano()
+
+	resetAt := atomic.LoadInt64(&c.resetAt)
+	if resetAt > nanos {
+		return atomic.AddUint64(&c.count, 1)
+	}
+
+	atomic.StoreUint64(&c.count, 1)
+
+	if !atomic.CompareAndSwapInt64(&c.resetAt, resetAt, nanos+tick.Nanoseconds()) {
+		// NOTE: another goroutine has reset the counter.
+		return atomic.AddUint64(&c.count, 1)
+	}
+
+	return 1
+}
+
+// sampler logs the first N log entries per (severity, message) per tick and then every Mth log entry.
+//
+// NOTE: (severity, message) is hashed to a fixed number of counters, so different messages may share the counter.
+type sampler struct {
+	// NOTE: counters and sampledOut are accessed atomically, so they are placed at the top of the struct for 64-bit alignment.
+	counters   [samplingCounters]samplingCounter
+	sampledOut uint64
+
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+}
+
+// samplerIfNeeded returns s if it is configured the same as config, otherwise returns new sampler. If config.SamplingTick is not set, returns nil.
+func samplerIfNeeded(s *sampler, config *Config) *sampler {
+	if config.SamplingTick <= 0 {
+		return nil
+	}
+
+	first, thereafter := uint64(config.SamplingFirst), uint64(config.SamplingThereafter)
+	if s != nil && s.tick == config.SamplingTick && s.first == first && s.thereafter == thereafter {
+		return s
+	}
+
+	return &sampler{
+		tick:       config.SamplingTick,
+		first:      first,
+		thereafter: thereafter,
+	}
+}
+
+// allow reports whether the log entry should be written.
+func (s *sampler) allow(now time.Time, severity Severity, message string) bool {
+	hash := uint32(fnv32aOffset)
+	for i := 0; i < len(message); i++ {
+		hash ^= uint32(message[i])
+		hash *= fnv32aPrime
+	}
+
+	hash ^= uint32(severity)
+	hash *= fnv32aPrime
+
+	n := s.counters[hash%samplingCounters].incr(now, s.tick)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+
+	atomic.AddUint64(&s.sampledOut, 1)
+
+	return false
+}
+
+// SampledOut returns the number of log entries that were not written by sampling.
+// Note that `*rec.Logger` copied by Copy, With, Renew, etc. shares the count unless the sampling config is changed.
+func (l *Logger) SampledOut() uint64 {
+	if l.sampler == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&l.sampler.sampledOut)
+}
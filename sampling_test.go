@@ -0,0 +1,143 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_samplerIfNeeded(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Disabled)", func(t *testing.T) {
+		t.Parallel()
+
+		actual := samplerIfNeeded(nil, NewConfig())
+		FailIfNotEqual(t, (*sampler)(nil), actual)
+	})
+
+	t.Run("success(Reuse)", func(t *testing.T) {
+		t.Parallel()
+
+		config := NewConfig()
+		config.SamplingTick, config.SamplingFirst, config.SamplingThereafter = time.Second, 1, 10
+		s := samplerIfNeeded(nil, config)
+		FailIfEqual(t, (*sampler)(nil), s)
+		FailIfNotEqual(t, s, samplerIfNeeded(s, config))
+
+		config.SamplingThereafter = 100
+		FailIfEqual(t, s, samplerIfNeeded(s, config))
+	})
+}
+
+func Test_sampler_allow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(FirstThereafter)", func(t *testing.T) {
+		t.Parallel()
+
+		s := &sampler{tick: time.Second, first: 2, thereafter: 3}
+		now := time.Unix(0, 0)
+
+		var actual []bool
+		for i := 0; i < 9; i++ {
+			actual = append(actual, s.allow(now, WARNING, "test"))
+		}
+		FailIfNotDeepEqual(t, []bool{true, true, false, false, true, false, false, true, false}, actual)
+		FailIfNotEqual(t, uint64(5), s.sampledOut)
+
+		// other severity and message are counted separately
+		FailIfNotEqual(t, true, s.allow(now, ERROR, "test"))
+		FailIfNotEqual(t, true, s.allow(now, WARNING, "other"))
+
+		// counters are reset after tick
+		FailIfNotEqual(t, true, s.allow(now.Add(time.Second), WARNING, "test"))
+	})
+
+	t.Run("success(NoThereafter)", func(t *testing.T) {
+		t.Parallel()
+
+		s := &sampler{tick: time.Second, first: 1, thereafter: 0}
+		now := time.Unix(0, 0)
+
+		FailIfNotEqual(t, true, s.allow(now, WARNING, "test"))
+		for i := 0; i < 100; i++ {
+			FailIfNotEqual(t, false, s.allow(now, WARNING, "test"))
+		}
+		FailIfNotEqual(t, uint64(100), s.sampledOut)
+	})
+
+	t.Run("success(Concurrent)", func(t *testing.T) {
+		t.Parallel()
+
+		s := &sampler{tick: time.Hour, first: 10, thereafter: 0}
+		now := time.Now()
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			allowed int
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if s.allow(now, WARNING, "test") {
+						mu.Lock()
+						allowed++
+						mu.Unlock()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		FailIfNotEqual(t, 10, allowed)
+		FailIfNotEqual(t, uint64(990), s.sampledOut)
+	})
+}
+
+func TestLogger_SampledOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithSampling(time.Hour, 2, 0)))
+
+		for i := 0; i < 5; i++ {
+			l.Warning("test")
+			l.With(String("with", "value")).Warning("test")
+		}
+
+		FailIfNotEqual(t, 2, strings.Count(buf.String(), defaultLineSeparator))
+		FailIfNotEqual(t, uint64(8), l.SampledOut())
+
+		renewed := Must(l.Renew(WithSampling(time.Hour, 1, 0)))
+		renewed.Warning("test")
+		FailIfNotEqual(t, 3, strings.Count(buf.String(), defaultLineSeparator))
+		FailIfNotEqual(t, uint64(0), renewed.SampledOut())
+	})
+
+	t.Run("success(Disabled)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(bytes.NewBuffer(nil)))
+		l.Warning("test")
+		FailIfNotEqual(t, uint64(0), l.SampledOut())
+	})
+}
+
+// nolint: paralleltest
+func TestLogger_SampledOut_NoAllocation(t *testing.T) {
+	l := Must(New(bytes.NewBuffer(nil), WithSampling(time.Hour, 1, 0)))
+	l.Warning("test")
+
+	actual := testing.AllocsPerRun(100, func() { l.Warning("test", String("key", "value")) })
+	FailIfNotEqual(t, float64(0), actual)
+}
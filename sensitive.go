@@ -0,0 +1,101 @@
+package rec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	sensitiveMask       = "********"
+	sensitiveHashPrefix = "sha256:"
+)
+
+// SensitivePolicy is the policy to render the values of rec.Secret and rec.PII.
+type SensitivePolicy string
+
+const (
+	// SensitivePolicyOmit omits the field.
+	SensitivePolicyOmit SensitivePolicy = "omit"
+	// SensitivePolicyMask renders the value as `********`.
+	SensitivePolicyMask SensitivePolicy = "mask"
+	// SensitivePolicyHash renders the value as `sha256:HEX` that is SHA-256 of `config.SensitiveFieldHashSalt` + the value.
+	// It can be used to correlate log entries without revealing the value.
+	SensitivePolicyHash SensitivePolicy = "hash"
+	// SensitivePolicyClear renders the value as it is. Use it only for local development.
+	SensitivePolicyClear SensitivePolicy = "clear"
+)
+
+func (p SensitivePolicy) valid() bool {
+	switch p {
+	case "", SensitivePolicyOmit, SensitivePolicyMask, SensitivePolicyHash, SensitivePolicyClear:
+		return true
+	default:
+		return false
+	}
+}
+
+// sensitiveField returns the field that the value of rec.Secret and rec.PII is rendered by the policy, and false if the field is omitted.
+// The fields in rec.Group are rendered by the policy too. The other fields are returned as they are.
+func (l *Logger) sensitiveField(f Field) (Field, bool) {
+	var policy SensitivePolicy
+
+	switch f.t { // nolint: exhaustive
+	case typeSecret:
+		policy = l.config.SecretFieldPolicy
+	case typePII:
+		policy = l.config.PIIFieldPolicy
+	case typeGroup:
+		return l.sensitiveGroup(f), true
+	default:
+		return f, true
+	}
+
+	switch policy {
+	case SensitivePolicyOmit:
+		return f, false
+	case SensitivePolicyClear:
+		return String(f.key, f.stringvalue1), true
+	case SensitivePolicyHash:
+		sum := sha256.Sum256([]byte(l.config.SensitiveFieldHashSalt + f.stringvalue1))
+
+		return String(f.key, sensitiveHashPrefix+hex.EncodeToString(sum[:])), true
+	case SensitivePolicyMask:
+		fallthrough
+	default:
+		return String(f.key, sensitiveMask), true
+	}
+}
+
+// sensitiveGroup returns rec.Group that the policy is applied to the fields recursively.
+// If rec.Group has no rec.Secret and rec.PII, it is returned as it is, in order not to allocate.
+func (l *Logger) sensitiveGroup(f Field) Field {
+	fields, _ := f.interfacevalue1.([]Field)
+	if !containsSensitiveField(fields) {
+		return f
+	}
+
+	rendered := make([]Field, 0, len(fields))
+
+	for i := range fields {
+		if field, ok := l.sensitiveField(fields[i]); ok {
+			rendered = append(rendered, field)
+		}
+	}
+
+	return Group(f.key, rendered...)
+}
+
+func containsSensitiveField(fields []Field) bool {
+	for i := range fields {
+		switch fields[i].t { // nolint: exhaustive
+		case typeSecret, typePII:
+			return true
+		case typeGroup:
+			if nested, _ := fields[i].interfacevalue1.([]Field); containsSensitiveField(nested) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLogger_sensitiveField(t *testing.T) {
+	t.Parallel()
+
+	// NOTE: echo -n "saltalice@example.com" | sha256sum
+	const hashed = "sha256:109f0b7ded1d94140eda40c1286befd64aec56290dba9e6642f3d096e9fc3b05"
+
+	tests := []struct {
+		name   string
+		secret SensitivePolicy
+		pii    SensitivePolicy
+		expect string
+	}{
+		{"success(Mask)", SensitivePolicyMask, SensitivePolicyMask, `{"severity":"INFO","message":"test","context":"********","password":"********","email":"********","user":"alice"}`},
+		{"success(Omit)", SensitivePolicyOmit, SensitivePolicyOmit, `{"severity":"INFO","message":"test","user":"alice"}`},
+		{"success(Hash)", SensitivePolicyOmit, SensitivePolicyHash, `{"severity":"INFO","message":"test","email":"` + hashed + `","user":"alice"}`},
+		{"success(Clear)", SensitivePolicyClear, SensitivePolicyClear, `{"severity":"INFO","message":"test","context":"ctx","password":"p@ss","email":"alice@example.com","user":"alice"}`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := bytes.NewBuffer(nil)
+			l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithSensitiveFieldPolicy(tt.secret, tt.pii), WithSensitiveFieldHashSalt("salt")))
+			l.With(Secret("context", "ctx")).Info("test", Secret("password", "p@ss"), PII("email", "alice@example.com"), String("user", "alice"))
+
+			FailIfNotEqual(t, tt.expect+defaultLineSeparator, buf.String())
+		})
+	}
+
+	t.Run("success(Group)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithSensitiveFieldPolicy(SensitivePolicyOmit, SensitivePolicyHash), WithSensitiveFieldHashSalt("salt")))
+		l.With(Group("context", Secret("token", "t"))).Info("test", Group("user", String("name", "alice"), Group("contact", PII("email", "alice@example.com"), Secret("password", "p@ss"))))
+
+		FailIfNotEqual(t, `{"severity":"INFO","message":"test","context":{},"user":{"name":"alice","contact":{"email":"`+hashed+`"}}}`+defaultLineSeparator, buf.String())
+	})
+
+	t.Run("success(GroupWithoutSensitive)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(bytes.NewBuffer(nil)))
+		group := Group("user", String("name", "alice"))
+
+		field, ok := l.sensitiveField(group)
+		FailIfNotEqual(t, true, ok)
+		FailIfNotDeepEqual(t, group, field)
+	})
+
+	t.Run("error(HashWithoutSalt)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(bytes.NewBuffer(nil), WithSensitiveFieldPolicy(SensitivePolicyMask, SensitivePolicyHash))
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+	})
+
+	t.Run("success(Default)", func(t *testing.T) {
+		t.Parallel()
+
+		l := Must(New(bytes.NewBuffer(nil)))
+		l.config.SecretFieldPolicy = ""
+
+		field, ok := l.sensitiveField(Secret("password", "p@ss"))
+		FailIfNotEqual(t, true, ok)
+		FailIfNotEqual(t, String("password", sensitiveMask), field)
+	})
+}
+
+func TestLogger_sensitiveField_formats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Logfmt)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatLogfmt), WithUseTimestampField(false), WithUseCallerField(false), WithSensitiveFieldPolicy(SensitivePolicyMask, SensitivePolicyOmit)))
+		l.Info("test", Secret("password", "p@ss"), PII("email", "alice@example.com"))
+
+		FailIfNotEqual(t, `severity=INFO message=test password=********`+defaultLineSeparator, buf.String())
+	})
+
+	t.Run("success(Console)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithFormat(FormatConsole), WithUseColor(false), WithUseTimestampField(false), WithUseCallerField(false), WithSensitiveFieldPolicy(SensitivePolicyOmit, SensitivePolicyClear)))
+		l.Info("test", Secret("password", "p@ss"), PII("email", "alice@example.com"))
+
+		FailIfNotEqual(t, `INFO      test email="alice@example.com"`+defaultLineSeparator, buf.String())
+	})
+
+	t.Run("success(WithThenRenew)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithSensitiveFieldPolicy(SensitivePolicyClear, SensitivePolicyClear)))
+		l = l.With(Secret("pw", "hunter2"), PII("email", "alice@example.com"))
+		l.Info("clear")
+		Must(l.Renew(WithSensitiveFieldPolicy(SensitivePolicyOmit, SensitivePolicyOmit))).Info("omit")
+		Must(l.Renew(WithFormat(FormatLogfmt), WithSensitiveFieldPolicy(SensitivePolicyMask, SensitivePolicyOmit))).Info("mask")
+
+		const expect = `{"severity":"INFO","message":"clear","pw":"hunter2","email":"alice@example.com"}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"omit"}` + defaultLineSeparator +
+			`severity=INFO message=mask pw=********` + defaultLineSeparator
+		FailIfNotEqual(t, expect, buf.String())
+	})
+}
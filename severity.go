@@ -3,6 +3,7 @@ package rec
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Severity controls the severity of the log output by `*rec.Logger`.
@@ -161,3 +162,37 @@ func (l *Logger) AddCustomSeverity(severity Severity, lowercase, uppercase strin
 
 	return nil
 }
+
+// ParseSeverity returns the Severity that s represents. s is matched case-insensitively in the following order:
+//
+//   - the lowercase or uppercase string of the severities including custom severities added by AddCustomSeverity.
+//   - the common text levels of other ecosystems, e.g. `trace`, `warn`, `err`, `crit`, `fatal`, `emerg`, `panic`. cf. rec.SeverityFromText
+//   - an integer, that is returned as Severity as it is.
+func (l *Logger) ParseSeverity(s string) (Severity, error) {
+	if severity, ok := l.lookupSeverity(s); ok {
+		return severity, nil
+	}
+
+	if severity, err := SeverityFromText(s); err == nil {
+		return severity, nil
+	}
+
+	if i, err := strconv.Atoi(s); err == nil {
+		return Severity(i), nil
+	}
+
+	return 0, fmt.Errorf("severity=%s: %w", s, ErrUnknownSeverity)
+}
+
+func (l *Logger) lookupSeverity(s string) (Severity, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	for severity, severityStrings := range l.customSeverities {
+		if strings.EqualFold(s, severityStrings.lowercase) || strings.EqualFold(s, severityStrings.uppercase) {
+			return severity, true
+		}
+	}
+
+	return 0, false
+}
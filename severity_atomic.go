@@ -0,0 +1,33 @@
+package rec
+
+import "sync/atomic"
+
+// AtomicSeverity is a severity threshold that can be changed at runtime without lock.
+// It can be shared by multiple `*rec.Logger` via WithAtomicSeverityThreshold.
+type AtomicSeverity struct {
+	severity int64
+}
+
+// NewAtomicSeverity returns `*rec.AtomicSeverity` initialized with the severity.
+func NewAtomicSeverity(severity Severity) *AtomicSeverity {
+	return &AtomicSeverity{severity: int64(severity)}
+}
+
+// Severity returns the current severity.
+func (a *AtomicSeverity) Severity() Severity {
+	return Severity(atomic.LoadInt64(&a.severity))
+}
+
+// SetSeverity changes the severity. The change takes effect immediately for all `*rec.Logger` that share the `*rec.AtomicSeverity`.
+func (a *AtomicSeverity) SetSeverity(severity Severity) {
+	atomic.StoreInt64(&a.severity, int64(severity))
+}
+
+// severityThreshold returns `config.AtomicSeverityThreshold` if set, otherwise `config.SeverityThreshold`.
+func (l *Logger) severityThreshold() Severity {
+	if l.config.AtomicSeverityThreshold != nil {
+		return l.config.AtomicSeverityThreshold.Severity()
+	}
+
+	return l.config.SeverityThreshold
+}
@@ -0,0 +1,93 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAtomicSeverity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		a := NewAtomicSeverity(INFO)
+		FailIfNotEqual(t, INFO, a.Severity())
+		a.SetSeverity(DEBUG)
+		FailIfNotEqual(t, DEBUG, a.Severity())
+	})
+
+	t.Run("success(SharedByLoggers)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		a := NewAtomicSeverity(WARNING)
+		l := Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithSeverityThreshold(EMERGENCY), WithAtomicSeverityThreshold(a)))
+		child := l.With(String("child", "true"))
+		renewed := Must(l.Renew(WithUseSeverityField(false)))
+
+		for _, logger := range []*Logger{l, child, renewed} {
+			logger.Info("before")
+		}
+
+		a.SetSeverity(DEBUG)
+
+		for _, logger := range []*Logger{l, child, renewed} {
+			logger.Info("after")
+		}
+
+		const expect = `{"severity":"INFO","message":"after"}` + defaultLineSeparator +
+			`{"severity":"INFO","message":"after","child":"true"}` + defaultLineSeparator +
+			`{"message":"after"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(Concurrent)", func(t *testing.T) {
+		t.Parallel()
+
+		w := &syncBuffer{}
+		a := NewAtomicSeverity(INFO)
+		l := Must(New(w, WithAtomicSeverityThreshold(a)))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				l.With(String("key", "value")).Info("test")
+			}()
+			go func(i int) {
+				defer wg.Done()
+				a.SetSeverity(Severity(i * 100))
+			}(i)
+		}
+		wg.Wait()
+
+		a.SetSeverity(INFO)
+		l.Info("last")
+		FailIfNotEqual(t, true, strings.Contains(w.String(), `"message":"last"`))
+	})
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *syncBuffer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.Write(p)
+}
+
+func (w *syncBuffer) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.String()
+}
@@ -0,0 +1,197 @@
+package rec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Syslog severities. cf. https://datatracker.ietf.org/doc/html/rfc5424#section-6.2.1
+const (
+	syslogEmergency = iota
+	syslogAlert
+	syslogCritical
+	syslogError
+	syslogWarning
+	syslogNotice
+	syslogInformational
+	syslogDebug
+)
+
+// OpenTelemetry SeverityNumber. cf. https://opentelemetry.io/docs/specs/otel/logs/data-model/#field-severitynumber
+const (
+	otelUnspecified = 0
+	otelTrace       = 1
+	otelDebug       = 5
+	otelInfo        = 9
+	otelInfo2       = 10
+	otelWarn        = 13
+	otelError       = 17
+	otelFatal       = 21
+	otelFatal3      = 23
+	otelFatal4      = 24
+)
+
+// textSeverities is the mapping table from the common text levels of other ecosystems (syslog, OpenTelemetry, zap, logrus, etc.) to Severity.
+// nolint: gochecknoglobals
+var textSeverities = map[string]Severity{
+	lowercaseDefault:   DEFAULT,
+	"trace":            DEBUG,
+	lowercaseDebug:     DEBUG,
+	lowercaseInfo:      INFO,
+	"informational":    INFO,
+	lowercaseNotice:    NOTICE,
+	"warn":             WARNING,
+	lowercaseWarning:   WARNING,
+	"err":              ERROR,
+	lowercaseError:     ERROR,
+	"dpanic":           CRITICAL,
+	"crit":             CRITICAL,
+	lowercaseCritical:  CRITICAL,
+	"fatal":            CRITICAL,
+	lowercaseAlert:     ALERT,
+	"emerg":            EMERGENCY,
+	"panic":            EMERGENCY,
+	lowercaseEmergency: EMERGENCY,
+}
+
+// SeverityFromText returns the Severity of the common text level case-insensitively:
+//
+//	default                         DEFAULT
+//	trace, debug                    DEBUG
+//	info, informational             INFO
+//	notice                          NOTICE
+//	warn, warning                   WARNING
+//	err, error                      ERROR
+//	dpanic, crit, critical, fatal   CRITICAL
+//	alert                           ALERT
+//	emerg, panic, emergency         EMERGENCY
+//
+// Note that `panic` is the deprecated synonym for `emerg` in syslog.
+func SeverityFromText(s string) (Severity, error) {
+	severity, ok := textSeverities[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("text=%s: %w", s, ErrUnknownSeverity)
+	}
+
+	return severity, nil
+}
+
+// SeverityFromSyslog returns the Severity of the syslog severity (RFC 5424, 0-7).
+func SeverityFromSyslog(syslogSeverity int) (Severity, error) {
+	switch syslogSeverity {
+	case syslogEmergency:
+		return EMERGENCY, nil
+	case syslogAlert:
+		return ALERT, nil
+	case syslogCritical:
+		return CRITICAL, nil
+	case syslogError:
+		return ERROR, nil
+	case syslogWarning:
+		return WARNING, nil
+	case syslogNotice:
+		return NOTICE, nil
+	case syslogInformational:
+		return INFO, nil
+	case syslogDebug:
+		return DEBUG, nil
+	default:
+		return 0, fmt.Errorf("syslogSeverity=%d: %w", syslogSeverity, ErrUnknownSeverity)
+	}
+}
+
+// Syslog returns the syslog severity (RFC 5424, 0-7) of the Severity.
+// The Severity between the predefined severities, e.g. custom severities, is rounded down to the nearest predefined severity.
+// DEFAULT and the Severity less than INFO are Debug (7).
+func (s Severity) Syslog() int {
+	switch {
+	case s >= EMERGENCY:
+		return syslogEmergency
+	case s >= ALERT:
+		return syslogAlert
+	case s >= CRITICAL:
+		return syslogCritical
+	case s >= ERROR:
+		return syslogError
+	case s >= WARNING:
+		return syslogWarning
+	case s >= NOTICE:
+		return syslogNotice
+	case s >= INFO:
+		return syslogInformational
+	default:
+		return syslogDebug
+	}
+}
+
+// SeverityFromOpenTelemetry returns the Severity of the OpenTelemetry SeverityNumber (0-24):
+//
+//	0 (UNSPECIFIED)          DEFAULT
+//	1-8 (TRACE-DEBUG4)       DEBUG
+//	9 (INFO)                 INFO
+//	10-12 (INFO2-INFO4)      NOTICE
+//	13-16 (WARN-WARN4)       WARNING
+//	17-20 (ERROR-ERROR4)     ERROR
+//	21-22 (FATAL-FATAL2)     CRITICAL
+//	23 (FATAL3)              ALERT
+//	24 (FATAL4)              EMERGENCY
+func SeverityFromOpenTelemetry(severityNumber int) (Severity, error) {
+	switch {
+	case severityNumber == otelUnspecified:
+		return DEFAULT, nil
+	case severityNumber < otelUnspecified:
+		return 0, fmt.Errorf("severityNumber=%d: %w", severityNumber, ErrUnknownSeverity)
+	case severityNumber < otelInfo:
+		return DEBUG, nil
+	case severityNumber < otelInfo2:
+		return INFO, nil
+	case severityNumber < otelWarn:
+		return NOTICE, nil
+	case severityNumber < otelError:
+		return WARNING, nil
+	case severityNumber < otelFatal:
+		return ERROR, nil
+	case severityNumber < otelFatal3:
+		return CRITICAL, nil
+	case severityNumber < otelFatal4:
+		return ALERT, nil
+	case severityNumber == otelFatal4:
+		return EMERGENCY, nil
+	default:
+		return 0, fmt.Errorf("severityNumber=%d: %w", severityNumber, ErrUnknownSeverity)
+	}
+}
+
+// OpenTelemetry returns the OpenTelemetry SeverityNumber (0-24) of the Severity. It is the inverse of SeverityFromOpenTelemetry.
+// The Severity between the predefined severities, e.g. custom severities, is rounded down to the nearest predefined severity,
+// and the Severity between DEFAULT and DEBUG is TRACE (1).
+func (s Severity) OpenTelemetry() int {
+	switch {
+	case s >= EMERGENCY:
+		return otelFatal4
+	case s >= ALERT:
+		return otelFatal3
+	case s >= CRITICAL:
+		return otelFatal
+	case s >= ERROR:
+		return otelError
+	case s >= WARNING:
+		return otelWarn
+	case s >= NOTICE:
+		return otelInfo2
+	case s >= INFO:
+		return otelInfo
+	case s >= DEBUG:
+		return otelDebug
+	case s > DEFAULT:
+		return otelTrace
+	default:
+		return otelUnspecified
+	}
+}
+
+// ParseSeverity returns the Severity that s represents, including custom severities added to the default logger.
+// cf. (*rec.Logger).ParseSeverity
+func ParseSeverity(s string) (Severity, error) {
+	return defaultLogger.ParseSeverity(s)
+}
@@ -0,0 +1,210 @@
+// nolint: testpackage
+package rec
+
+import (
+	"testing"
+)
+
+func TestSeverityFromText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s              string
+		expectSeverity Severity
+		expectError    error
+	}{
+		{"default", DEFAULT, nil},
+		{"trace", DEBUG, nil},
+		{"DEBUG", DEBUG, nil},
+		{"info", INFO, nil},
+		{"Informational", INFO, nil},
+		{"notice", NOTICE, nil},
+		{"WARN", WARNING, nil},
+		{"warning", WARNING, nil},
+		{"err", ERROR, nil},
+		{"error", ERROR, nil},
+		{"dpanic", CRITICAL, nil},
+		{"crit", CRITICAL, nil},
+		{"critical", CRITICAL, nil},
+		{"fatal", CRITICAL, nil},
+		{"alert", ALERT, nil},
+		{"emerg", EMERGENCY, nil},
+		{"panic", EMERGENCY, nil},
+		{"emergency", EMERGENCY, nil},
+		{"unknown", 0, ErrUnknownSeverity},
+		{"100", 0, ErrUnknownSeverity},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := SeverityFromText(tt.s)
+			FailIfNotErrorIs(t, tt.expectError, err)
+			FailIfNotEqual(t, tt.expectSeverity, actual)
+		})
+	}
+}
+
+func TestSeverityFromSyslog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		syslogSeverity int
+		expectSeverity Severity
+		expectError    error
+	}{
+		{"success(Emergency)", 0, EMERGENCY, nil},
+		{"success(Alert)", 1, ALERT, nil},
+		{"success(Critical)", 2, CRITICAL, nil},
+		{"success(Error)", 3, ERROR, nil},
+		{"success(Warning)", 4, WARNING, nil},
+		{"success(Notice)", 5, NOTICE, nil},
+		{"success(Informational)", 6, INFO, nil},
+		{"success(Debug)", 7, DEBUG, nil},
+		{"error(-1)", -1, 0, ErrUnknownSeverity},
+		{"error(8)", 8, 0, ErrUnknownSeverity},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := SeverityFromSyslog(tt.syslogSeverity)
+			FailIfNotErrorIs(t, tt.expectError, err)
+			FailIfNotEqual(t, tt.expectSeverity, actual)
+
+			if err == nil {
+				FailIfNotEqual(t, tt.syslogSeverity, actual.Syslog())
+			}
+		})
+	}
+}
+
+func TestSeverity_Syslog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		severity Severity
+		expect   int
+	}{
+		{"success(DEFAULT)", DEFAULT, 7},
+		{"success(custom,50)", 50, 7},
+		{"success(custom,450)", 450, 4},
+		{"success(EMERGENCY+1)", EMERGENCY + 1, 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			FailIfNotEqual(t, tt.expect, tt.severity.Syslog())
+		})
+	}
+}
+
+func TestSeverityFromOpenTelemetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		severityNumber int
+		expectSeverity Severity
+		expectError    error
+	}{
+		{"success(UNSPECIFIED)", 0, DEFAULT, nil},
+		{"success(TRACE)", 1, DEBUG, nil},
+		{"success(DEBUG4)", 8, DEBUG, nil},
+		{"success(INFO)", 9, INFO, nil},
+		{"success(INFO2)", 10, NOTICE, nil},
+		{"success(INFO4)", 12, NOTICE, nil},
+		{"success(WARN)", 13, WARNING, nil},
+		{"success(ERROR)", 17, ERROR, nil},
+		{"success(FATAL)", 21, CRITICAL, nil},
+		{"success(FATAL2)", 22, CRITICAL, nil},
+		{"success(FATAL3)", 23, ALERT, nil},
+		{"success(FATAL4)", 24, EMERGENCY, nil},
+		{"error(-1)", -1, 0, ErrUnknownSeverity},
+		{"error(25)", 25, 0, ErrUnknownSeverity},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := SeverityFromOpenTelemetry(tt.severityNumber)
+			FailIfNotErrorIs(t, tt.expectError, err)
+			FailIfNotEqual(t, tt.expectSeverity, actual)
+		})
+	}
+}
+
+func TestSeverity_OpenTelemetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		severity Severity
+		expect   int
+	}{
+		{"success(DEFAULT)", DEFAULT, 0},
+		{"success(custom,50)", 50, 1},
+		{"success(DEBUG)", DEBUG, 5},
+		{"success(INFO)", INFO, 9},
+		{"success(NOTICE)", NOTICE, 10},
+		{"success(WARNING)", WARNING, 13},
+		{"success(ERROR)", ERROR, 17},
+		{"success(CRITICAL)", CRITICAL, 21},
+		{"success(ALERT)", ALERT, 23},
+		{"success(EMERGENCY)", EMERGENCY, 24},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			FailIfNotEqual(t, tt.expect, tt.severity.OpenTelemetry())
+
+			// NOTE: round trip
+			if tt.severity%100 == 0 {
+				actual, err := SeverityFromOpenTelemetry(tt.expect)
+				FailIfNotErrorIs(t, nil, err)
+				FailIfNotEqual(t, tt.severity, actual)
+			}
+		})
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		s              string
+		expectSeverity Severity
+		expectError    error
+	}{
+		{"success(uppercase)", "NOTICE", NOTICE, nil},
+		{"success(text)", "trace", DEBUG, nil},
+		{"success(integer)", "-1", -1, nil},
+		{"error(ErrUnknownSeverity)", "unknown", 0, ErrUnknownSeverity},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := ParseSeverity(tt.s)
+			FailIfNotErrorIs(t, tt.expectError, err)
+			FailIfNotEqual(t, tt.expectSeverity, actual)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package rec
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const severityHandlerMaxRequestBytes = 1 << 10
+
+// SeverityHandler is an http.Handler that reports and changes `config.AtomicSeverityThreshold` of `*rec.Logger`.
+//
+//	GET                       returns the current severity.
+//	PUT, POST                 changes the severity. The request is JSON `{"severity":"debug","ttl":"10m"}` or form `severity=debug&ttl=10m`.
+//
+// The severity is the lowercase or uppercase string including custom severities, or an integer.
+// If ttl is set, the previous severity is restored after ttl. The expiry is judged by `config.Clock` of the `*rec.Logger`.
+// The request body is limited to 1 KiB.
+type SeverityHandler struct {
+	l        *Logger
+	severity *AtomicSeverity
+
+	mu         sync.Mutex
+	generation uint64
+	timer      *time.Timer
+	restore    Severity
+	expiresAt  time.Time
+}
+
+// NewSeverityHandler returns `*rec.SeverityHandler` for the `*rec.Logger` that `config.AtomicSeverityThreshold` is set.
+func NewSeverityHandler(l *Logger) (*SeverityHandler, error) {
+	if l.config.AtomicSeverityThreshold == nil {
+		return nil, fmt.Errorf("*Config.AtomicSeverityThreshold %w", ErrIsEmpty)
+	}
+
+	return &SeverityHandler{
+		l:        l,
+		severity: l.config.AtomicSeverityThreshold,
+	}, nil
+}
+
+type severityHandlerRequest struct {
+	Severity string `json:"severity"`
+	TTL      string `json:"ttl,omitempty"`
+}
+
+type severityHandlerResponse struct {
+	Severity        string     `json:"severity,omitempty"`
+	RestoreSeverity string     `json:"restoreSeverity,omitempty"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *SeverityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.writeResponse(w, http.StatusOK, h.response())
+	case http.MethodPut, http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, severityHandlerMaxRequestBytes)
+
+		req, err := readSeverityHandlerRequest(r)
+		if err != nil {
+			h.writeResponse(w, http.StatusBadRequest, severityHandlerResponse{Error: err.Error()})
+
+			return
+		}
+
+		severity, err := h.l.ParseSeverity(req.Severity)
+		if err != nil {
+			h.writeResponse(w, http.StatusBadRequest, severityHandlerResponse{Error: err.Error()})
+
+			return
+		}
+
+		var ttl time.Duration
+		if req.TTL != "" {
+			ttl, err = time.ParseDuration(req.TTL)
+			if err != nil || ttl <= 0 {
+				h.writeResponse(w, http.StatusBadRequest, severityHandlerResponse{Error: fmt.Sprintf("ttl=%s: invalid duration", req.TTL)})
+
+				return
+			}
+		}
+
+		h.SetSeverity(severity, ttl)
+		h.writeResponse(w, http.StatusOK, h.response())
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		h.writeResponse(w, http.StatusMethodNotAllowed, severityHandlerResponse{Error: fmt.Sprintf("method=%s: method not allowed", r.Method)})
+	}
+}
+
+// SetSeverity changes the severity. If ttl is greater than 0, the severity before the first unexpired change is restored after ttl.
+// The timer runs in real time, but the severity is restored only when `config.Clock` reaches the expiry, so that it is consistent with expiresAt.
+func (h *SeverityHandler) SetSeverity(severity Severity, ttl time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.expire()
+	h.generation++
+
+	if h.timer != nil {
+		h.timer.Stop()
+		h.timer = nil
+	} else {
+		h.restore = h.severity.Severity()
+	}
+
+	h.severity.SetSeverity(severity)
+
+	if ttl <= 0 {
+		h.expiresAt = time.Time{}
+
+		return
+	}
+
+	h.expiresAt = h.l.now().Add(ttl)
+	h.startTimer(ttl)
+}
+
+// startTimer starts the timer that restores the severity after d. h.mu must be locked.
+func (h *SeverityHandler) startTimer(d time.Duration) {
+	generation := h.generation
+	h.timer = time.AfterFunc(d, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		// NOTE: the severity has been changed again after this timer was set.
+		if generation != h.generation {
+			return
+		}
+
+		if h.expire() {
+			return
+		}
+
+		// NOTE: config.Clock has not reached the expiry yet, so wait for the rest of ttl by config.Clock.
+		h.startTimer(h.expiresAt.Sub(h.l.now()))
+	})
+}
+
+// expire restores the severity and reports true if the ttl has expired by `config.Clock`. h.mu must be locked.
+func (h *SeverityHandler) expire() bool {
+	if h.timer == nil || h.l.now().Before(h.expiresAt) {
+		return false
+	}
+
+	h.timer.Stop()
+	h.severity.SetSeverity(h.restore)
+	h.timer = nil
+	h.expiresAt = time.Time{}
+
+	return true
+}
+
+func (h *SeverityHandler) response() severityHandlerResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.expire()
+
+	res := severityHandlerResponse{Severity: h.severityString(h.severity.Severity())}
+
+	if h.timer != nil {
+		expiresAt := h.expiresAt
+		res.RestoreSeverity = h.severityString(h.restore)
+		res.ExpiresAt = &expiresAt
+	}
+
+	return res
+}
+
+func (h *SeverityHandler) severityString(severity Severity) string {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	if h.l.config.UseUppercaseSeverity {
+		return h.l.uppercase(severity)
+	}
+
+	return h.l.lowercase(severity)
+}
+
+func (h *SeverityHandler) writeResponse(w http.ResponseWriter, statusCode int, res severityHandlerResponse) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		err = fmt.Errorf("(*rec.SeverityHandler).writeResponse: (*json.Encoder).Encode: %w", err)
+		defaultLogger.write(ERROR, err.Error(), Error(err))
+	}
+}
+
+func readSeverityHandlerRequest(r *http.Request) (*severityHandlerRequest, error) {
+	var req severityHandlerRequest
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, fmt.Errorf("(*json.Decoder).Decode: %w", err)
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("(*http.Request).ParseForm: %w", err)
+		}
+
+		req.Severity = r.Form.Get("severity")
+		req.TTL = r.Form.Get("ttl")
+	}
+
+	if req.Severity == "" {
+		return nil, fmt.Errorf("severity %w", ErrIsEmpty)
+	}
+
+	return &req, nil
+}
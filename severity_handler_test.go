@@ -0,0 +1,190 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSeverityHandler(t *testing.T, options ...Option) (*SeverityHandler, *AtomicSeverity) {
+	t.Helper()
+
+	severity := NewAtomicSeverity(INFO)
+	l := Must(New(bytes.NewBuffer(nil), append([]Option{WithAtomicSeverityThreshold(severity)}, options...)...))
+
+	h, err := NewSeverityHandler(l)
+	FailIfNotErrorIs(t, nil, err)
+
+	return h, severity
+}
+
+func serveSeverityHandler(h http.Handler, method, contentType, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, "/severity", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestNewSeverityHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error(AtomicSeverityThreshold)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSeverityHandler(Must(New(bytes.NewBuffer(nil))))
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+	})
+}
+
+func TestSeverityHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(GET)", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newTestSeverityHandler(t)
+
+		w := serveSeverityHandler(h, http.MethodGet, "", "")
+		FailIfNotEqual(t, http.StatusOK, w.Code)
+		FailIfNotEqual(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		FailIfNotEqual(t, `{"severity":"INFO"}`+"\n", w.Body.String())
+	})
+
+	t.Run("success(PUT,JSON)", func(t *testing.T) {
+		t.Parallel()
+
+		h, severity := newTestSeverityHandler(t, WithUseUppercaseSeverity(false))
+
+		w := serveSeverityHandler(h, http.MethodPut, "application/json", `{"severity":"DEBUG"}`)
+		FailIfNotEqual(t, http.StatusOK, w.Code)
+		FailIfNotEqual(t, `{"severity":"debug"}`+"\n", w.Body.String())
+		FailIfNotEqual(t, DEBUG, severity.Severity())
+	})
+
+	t.Run("success(POST,Form,CustomSeverity)", func(t *testing.T) {
+		t.Parallel()
+
+		h, severity := newTestSeverityHandler(t)
+		FailIfNotErrorIs(t, nil, h.l.AddCustomSeverity(150, "trace", "TRACE"))
+
+		w := serveSeverityHandler(h, http.MethodPost, "application/x-www-form-urlencoded", url.Values{"severity": {"trace"}}.Encode())
+		FailIfNotEqual(t, http.StatusOK, w.Code)
+		FailIfNotEqual(t, `{"severity":"TRACE"}`+"\n", w.Body.String())
+		FailIfNotEqual(t, Severity(150), severity.Severity())
+
+		w = serveSeverityHandler(h, http.MethodPost, "application/x-www-form-urlencoded", url.Values{"severity": {"250"}}.Encode())
+		FailIfNotEqual(t, http.StatusOK, w.Code)
+		FailIfNotEqual(t, `{"severity":"250"}`+"\n", w.Body.String())
+	})
+
+	t.Run("success(TTL)", func(t *testing.T) {
+		t.Parallel()
+
+		h, severity := newTestSeverityHandler(t)
+
+		w := serveSeverityHandler(h, http.MethodPut, "application/json", `{"severity":"debug","ttl":"1h"}`)
+		FailIfNotEqual(t, http.StatusOK, w.Code)
+		FailIfNotRegexpMatchString(t, regexp.MustCompile(`^{"severity":"DEBUG","restoreSeverity":"INFO","expiresAt":"[^"]+"}`+"\n$"), w.Body.String())
+
+		// NOTE: the severity before the first unexpired change is restored.
+		h.SetSeverity(NOTICE, 10*time.Millisecond)
+		FailIfNotEqual(t, NOTICE, severity.Severity())
+
+		deadline := time.Now().Add(5 * time.Second)
+		for severity.Severity() != INFO {
+			if time.Now().After(deadline) {
+				t.Fatalf("severity is not restored: %d", severity.Severity())
+			}
+
+			time.Sleep(time.Millisecond)
+		}
+
+		FailIfNotEqual(t, `{"severity":"INFO"}`+"\n", serveSeverityHandler(h, http.MethodGet, "", "").Body.String())
+	})
+
+	t.Run("success(TTL,Clock)", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newTestSeverityHandler(t, WithClock(NewFixedClock(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))))
+
+		w := serveSeverityHandler(h, http.MethodPut, "application/json", `{"severity":"debug","ttl":"1h"}`)
+		FailIfNotEqual(t, http.StatusOK, w.Code)
+		FailIfNotEqual(t, `{"severity":"DEBUG","restoreSeverity":"INFO","expiresAt":"2023-01-02T04:04:05Z"}`+"\n", w.Body.String())
+		h.SetSeverity(INFO, 0)
+	})
+
+	t.Run("success(TTL,FakeClock)", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewFakeClock(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), 0)
+		h, severity := newTestSeverityHandler(t, WithClock(clock))
+
+		// NOTE: the timer fires in real time, but the severity is not restored until the clock reaches the expiry.
+		h.SetSeverity(DEBUG, 10*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		FailIfNotEqual(t, DEBUG, severity.Severity())
+
+		clock.Add(10 * time.Millisecond)
+		FailIfNotEqual(t, `{"severity":"INFO"}`+"\n", serveSeverityHandler(h, http.MethodGet, "", "").Body.String())
+		FailIfNotEqual(t, INFO, severity.Severity())
+	})
+
+	t.Run("success(CancelTTL)", func(t *testing.T) {
+		t.Parallel()
+
+		h, severity := newTestSeverityHandler(t)
+
+		h.SetSeverity(DEBUG, 10*time.Millisecond)
+		h.SetSeverity(ERROR, 0)
+		time.Sleep(50 * time.Millisecond)
+		FailIfNotEqual(t, ERROR, severity.Severity())
+	})
+
+	t.Run("error(BadRequest)", func(t *testing.T) {
+		t.Parallel()
+
+		h, severity := newTestSeverityHandler(t)
+
+		tests := []struct {
+			contentType string
+			body        string
+			expect      string
+		}{
+			{"application/json", `{`, `{"error":"(*json.Decoder).Decode: unexpected EOF"}`},
+			{"application/json", `{}`, `{"error":"severity is empty"}`},
+			{"application/json", `{"severity":"unknown"}`, `{"error":"severity=unknown: unknown severity"}`},
+			{"application/json", `{"severity":"debug","ttl":"-1s"}`, `{"error":"ttl=-1s: invalid duration"}`},
+			{"application/x-www-form-urlencoded", `severity=%`, `{"error":"(*http.Request).ParseForm: invalid URL escape \"%\""}`},
+			{"application/json", `{"severity":"` + strings.Repeat("a", severityHandlerMaxRequestBytes) + `"}`, `{"error":"(*json.Decoder).Decode: http: request body too large"}`},
+			{"application/x-www-form-urlencoded", `severity=` + strings.Repeat("a", severityHandlerMaxRequestBytes), `{"error":"(*http.Request).ParseForm: http: request body too large"}`},
+		}
+		for _, tt := range tests {
+			w := serveSeverityHandler(h, http.MethodPut, tt.contentType, tt.body)
+			FailIfNotEqual(t, http.StatusBadRequest, w.Code)
+			FailIfNotEqual(t, tt.expect+"\n", w.Body.String())
+		}
+
+		FailIfNotEqual(t, INFO, severity.Severity())
+	})
+
+	t.Run("error(MethodNotAllowed)", func(t *testing.T) {
+		t.Parallel()
+
+		h, _ := newTestSeverityHandler(t)
+
+		w := serveSeverityHandler(h, http.MethodDelete, "", "")
+		FailIfNotEqual(t, http.StatusMethodNotAllowed, w.Code)
+		FailIfNotEqual(t, "GET, PUT, POST", w.Header().Get("Allow"))
+	})
+}
@@ -148,3 +148,37 @@ func TestLogger_AddCustomSeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger_ParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	l := Must(New(nil))
+	FailIfNotErrorIs(t, nil, l.AddCustomSeverity(50, "trace", "TRACE"))
+
+	tests := []struct {
+		name           string
+		s              string
+		expectSeverity Severity
+		expectError    error
+	}{
+		{"success(lowercase)", "debug", DEBUG, nil},
+		{"success(uppercase)", "WARNING", WARNING, nil},
+		{"success(mixedcase)", "Emergency", EMERGENCY, nil},
+		{"success(custom)", "trace", 50, nil},
+		{"success(text)", "warn", WARNING, nil},
+		{"success(text,uppercase)", "FATAL", CRITICAL, nil},
+		{"success(integer)", "250", 250, nil},
+		{"error(ErrUnknownSeverity)", "unknown", 0, ErrUnknownSeverity},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := l.ParseSeverity(tt.s)
+			FailIfNotErrorIs(t, tt.expectError, err)
+			FailIfNotEqual(t, tt.expectSeverity, actual)
+		})
+	}
+}
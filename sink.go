@@ -0,0 +1,241 @@
+package rec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Sink is a destination of `*rec.MultiSinkWriter`.
+type Sink struct {
+	// Writer is the io.Writer to write log entries to.
+	Writer io.Writer
+	// SeverityThreshold is the severity of the log entries written to Writer.
+	SeverityThreshold Severity
+	// Format is the output format of the log entries written to Writer. If empty, `config.Format` of `*rec.Logger` is used.
+	Format Format
+}
+
+// MultiSinkWriter is an io.Writer that writes log entries to multiple sinks.
+//
+// When `*rec.Logger` writes to `*rec.MultiSinkWriter`, each log entry is encoded once per format,
+// and written to the sinks whose SeverityThreshold is less than or equal to the severity of the log entry.
+// Note that the severity threshold of `*rec.Logger` is checked before the sinks.
+//
+// `*rec.MultiSinkWriter` is not wrapped with `*rec.AsyncWriter` even if `config.AsyncQueueSize` is set. Wrap each Sink.Writer instead.
+type MultiSinkWriter struct {
+	sinks []Sink
+}
+
+// NewMultiSinkWriter returns `*rec.MultiSinkWriter`.
+func NewMultiSinkWriter(sinks ...Sink) (*MultiSinkWriter, error) {
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("sinks %w", ErrIsEmpty)
+	}
+
+	for i := range sinks {
+		if sinks[i].Writer == nil {
+			return nil, fmt.Errorf("sinks[%d].Writer %w", i, ErrIsEmpty)
+		}
+
+		switch sinks[i].Format {
+		case "", FormatJSON, FormatConsole, FormatLogfmt:
+		default:
+			return nil, fmt.Errorf("sinks[%d].Format=%s: %w", i, sinks[i].Format, ErrUnknownFormat)
+		}
+	}
+
+	return &MultiSinkWriter{sinks: append([]Sink(nil), sinks...)}, nil
+}
+
+// SinkError is an error that occurred while writing to the sink.
+type SinkError struct {
+	Index  int
+	Writer io.Writer
+	Err    error
+}
+
+func (e *SinkError) Error() string {
+	return fmt.Sprintf("sinks[%d]: writer=%#v: %v", e.Index, e.Writer, e.Err)
+}
+
+func (e *SinkError) Unwrap() error {
+	return e.Err
+}
+
+// SinkErrors is errors that occurred while writing to the sinks.
+type SinkErrors []*SinkError
+
+func (e SinkErrors) Error() string {
+	s := make([]string, 0, len(e))
+	for i := range e {
+		s = append(s, e[i].Error())
+	}
+
+	return strings.Join(s, "; ")
+}
+
+// Is reports whether any error in SinkErrors matches target.
+func (e SinkErrors) Is(target error) bool {
+	for i := range e {
+		if errors.Is(e[i], target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Write writes p to all sinks regardless of the severity.
+// If any sink returns an error, Write returns `rec.SinkErrors` after writing to all sinks.
+func (w *MultiSinkWriter) Write(p []byte) (int, error) {
+	var errs SinkErrors
+
+	for i := range w.sinks {
+		if _, err := w.sinks[i].Writer.Write(p); err != nil {
+			errs = append(errs, &SinkError{Index: i, Writer: w.sinks[i].Writer, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return 0, errs
+	}
+
+	return len(p), nil
+}
+
+// Flush flushes the sinks that have `Flush(context.Context) error` or `Flush() error` method.
+func (w *MultiSinkWriter) Flush(ctx context.Context) error {
+	var errs SinkErrors
+
+	for i := range w.sinks {
+		var err error
+
+		switch sinkWriter := w.sinks[i].Writer.(type) {
+		case interface{ Flush(context.Context) error }:
+			err = sinkWriter.Flush(ctx)
+		case interface{ Flush() error }:
+			err = sinkWriter.Flush()
+		}
+
+		if err != nil {
+			errs = append(errs, &SinkError{Index: i, Writer: w.sinks[i].Writer, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Close closes the sinks that implement io.Closer.
+func (w *MultiSinkWriter) Close() error {
+	var errs SinkErrors
+
+	for i := range w.sinks {
+		if closer, ok := w.sinks[i].Writer.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, &SinkError{Index: i, Writer: w.sinks[i].Writer, Err: err})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Dropped returns the total number of log entries dropped by the sinks.
+func (w *MultiSinkWriter) Dropped() uint64 {
+	var dropped uint64
+
+	for i := range w.sinks {
+		if sinkWriter, ok := w.sinks[i].Writer.(interface{ Dropped() uint64 }); ok {
+			dropped += sinkWriter.Dropped()
+		}
+	}
+
+	return dropped
+}
+
+// sinkEncodedEntry is the log entry encoded in the format.
+type sinkEncodedEntry struct {
+	format Format
+	b      *buffer
+}
+
+// writeSinks encodes the log entry once per format, and writes it to the sinks that accept the severity.
+func (l *Logger) writeSinks(w *MultiSinkWriter, now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) {
+	// NOTE: there are only 3 formats, so the encoded entries are kept in the array on the stack.
+	var (
+		encoded [3]sinkEncodedEntry
+		n       int
+	)
+
+	defer func() {
+		for i := 0; i < n; i++ {
+			bufferPool.Put(encoded[i].b)
+		}
+	}()
+
+	for i := range w.sinks {
+		sink := &w.sinks[i]
+		if severity < sink.SeverityThreshold {
+			continue
+		}
+
+		if syslogWriter, ok := sink.Writer.(*SyslogWriter); ok {
+			l.writeSyslog(syslogWriter, now, severity, frame, message, fields)
+
+			continue
+		}
+
+		format := sink.Format
+		if format == "" {
+			format = l.config.Format
+		}
+
+		switch format {
+		case FormatConsole, FormatLogfmt:
+		default:
+			// NOTE: the same as appendEntry.
+			format = FormatJSON
+		}
+
+		var b *buffer
+
+		for j := 0; j < n; j++ {
+			if encoded[j].format == format {
+				b = encoded[j].b
+
+				break
+			}
+		}
+
+		if b == nil {
+			b = bufferPool.Get().(*buffer) // nolint: forcetypeassert
+			b.Buffer = l.appendEntry(b.Buffer[:0], format, now, severity, frame, message, fields)
+			encoded[n] = sinkEncodedEntry{format: format, b: b}
+			n++
+		}
+
+		// NOTE: *rec.AsyncWriter copies b in Write, so b can be shared by the sinks.
+		if _, err := sink.Writer.Write(b.Buffer); err != nil {
+			if defaultLogger.writer == w {
+				// NOTE: avoid recursion. The other sinks of the default logger have received the log entry.
+				continue
+			}
+
+			err = fmt.Errorf("(*rec.Logger).write: %w", &SinkError{Index: i, Writer: sink.Writer, Err: err})
+			defaultLogger.writeAt(now, ERROR, err.Error(), Error(err))
+		}
+	}
+}
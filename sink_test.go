@@ -0,0 +1,169 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+type errorWriter struct {
+	err error
+}
+
+func (w *errorWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func (w *errorWriter) Close() error {
+	return w.err
+}
+
+func TestNewMultiSinkWriter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		sinks  []Sink
+		expect error
+	}{
+		{"success()", []Sink{{Writer: bytes.NewBuffer(nil)}, {Writer: bytes.NewBuffer(nil), Format: FormatLogfmt}}, nil},
+		{"error(NoSink)", nil, ErrIsEmpty},
+		{"error(Writer)", []Sink{{Writer: nil}}, ErrIsEmpty},
+		{"error(Format)", []Sink{{Writer: bytes.NewBuffer(nil), Format: "unknown"}}, ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, actual := NewMultiSinkWriter(tt.sinks...)
+			FailIfNotErrorIs(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestLogger_writeSinks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+
+		stderr, file, socket, console := bytes.NewBuffer(nil), bytes.NewBuffer(nil), bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		w, err := NewMultiSinkWriter(
+			Sink{Writer: stderr, SeverityThreshold: ERROR},
+			Sink{Writer: file, SeverityThreshold: DEBUG},
+			Sink{Writer: socket, SeverityThreshold: INFO, Format: FormatLogfmt},
+			Sink{Writer: console, SeverityThreshold: INFO, Format: FormatConsole},
+		)
+		FailIfNotErrorIs(t, nil, err)
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false), WithUseColor(false)))
+		l.Debug("debug")
+		l.Info("info", String("key", "value"))
+		l.Error("error")
+
+		FailIfNotEqual(t, `{"severity":"ERROR","message":"error"}`+defaultLineSeparator, stderr.String())
+		FailIfNotEqual(t, `{"severity":"DEBUG","message":"debug"}`+defaultLineSeparator+`{"severity":"INFO","message":"info","key":"value"}`+defaultLineSeparator+`{"severity":"ERROR","message":"error"}`+defaultLineSeparator, file.String())
+		FailIfNotEqual(t, `severity=INFO message=info key=value`+defaultLineSeparator+`severity=ERROR message=error`+defaultLineSeparator, socket.String())
+		FailIfNotEqual(t, `INFO      info key="value"`+defaultLineSeparator+`ERROR     error`+defaultLineSeparator, console.String())
+	})
+
+	t.Run("success(Async)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		asyncWriter, err := NewAsyncWriter(buf, 16, AsyncPolicyBlock)
+		FailIfNotErrorIs(t, nil, err)
+		w, err := NewMultiSinkWriter(Sink{Writer: asyncWriter}, Sink{Writer: bytes.NewBuffer(nil)})
+		FailIfNotErrorIs(t, nil, err)
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false), WithAsync(16, AsyncPolicyBlock)))
+		FailIfNotEqual(t, w, l.writer.(*MultiSinkWriter)) // nolint: forcetypeassert
+		l.Info("test")
+		FailIfNotErrorIs(t, nil, l.Flush(context.Background()))
+		FailIfNotErrorIs(t, nil, asyncWriter.Close())
+
+		FailIfNotEqual(t, `{"severity":"INFO","message":"test"}`+defaultLineSeparator, buf.String())
+		FailIfNotEqual(t, uint64(0), l.Dropped())
+	})
+}
+
+// nolint: paralleltest
+func TestLogger_writeSinks_Error(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	rollback := ReplaceDefaultLogger(Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false))))
+	defer rollback()
+
+	ok := bytes.NewBuffer(nil)
+	w, err := NewMultiSinkWriter(Sink{Writer: &errorWriter{err: errForTest}}, Sink{Writer: ok})
+	FailIfNotErrorIs(t, nil, err)
+
+	l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+	l.Info("test")
+
+	const expect = `{"severity":"ERROR","message":"(*rec.Logger).write: sinks[0]: writer=&rec.errorWriter{err:(*errors.errorString)(0x`
+	FailIfNotEqual(t, expect, buf.String()[:len(expect)])
+	FailIfNotEqual(t, `{"severity":"INFO","message":"test"}`+defaultLineSeparator, ok.String())
+
+	// NOTE: the default logger that writes to the failing sink does not recurse.
+	rollbackSelf := ReplaceDefaultLogger(l)
+	defer rollbackSelf()
+
+	l.Info("test")
+	FailIfNotEqual(t, `{"severity":"INFO","message":"test"}`+defaultLineSeparator+`{"severity":"INFO","message":"test"}`+defaultLineSeparator, ok.String())
+}
+
+func TestMultiSinkWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Write,Flush,Close)", func(t *testing.T) {
+		t.Parallel()
+
+		buf1, buf2 := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		bufWriter := bufio.NewWriter(buf2)
+		w, err := NewMultiSinkWriter(Sink{Writer: buf1, SeverityThreshold: EMERGENCY}, Sink{Writer: bufWriter})
+		FailIfNotErrorIs(t, nil, err)
+
+		n, err := w.Write([]byte("test"))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotEqual(t, 4, n)
+		FailIfNotEqual(t, "test", buf1.String())
+		FailIfNotEqual(t, "", buf2.String())
+
+		FailIfNotErrorIs(t, nil, w.Flush(context.Background()))
+		FailIfNotEqual(t, "test", buf2.String())
+		FailIfNotErrorIs(t, nil, w.Close())
+	})
+
+	t.Run("error(Write,Flush,Close)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		w, err := NewMultiSinkWriter(Sink{Writer: &errorWriter{err: errForTest}}, Sink{Writer: buf}, Sink{Writer: &errorWriter{err: os.ErrClosed}})
+		FailIfNotErrorIs(t, nil, err)
+
+		_, err = w.Write([]byte("test"))
+		FailIfNotErrorIs(t, errForTest, err)
+		FailIfNotErrorIs(t, os.ErrClosed, err)
+		FailIfNotEqual(t, "test", buf.String())
+
+		var sinkErrors SinkErrors
+		FailIfNotEqual(t, true, errors.As(err, &sinkErrors))
+		FailIfNotEqual(t, 2, len(sinkErrors))
+		FailIfNotEqual(t, 2, sinkErrors[1].Index)
+
+		bufWriter := bufio.NewWriterSize(&errorWriter{err: errForTest}, 16)
+		_, _ = bufWriter.Write([]byte("test"))
+		w, err = NewMultiSinkWriter(Sink{Writer: bufWriter})
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotErrorIs(t, errForTest, w.Flush(context.Background()))
+
+		w, err = NewMultiSinkWriter(Sink{Writer: &errorWriter{err: errForTest}})
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotErrorIs(t, errForTest, w.Close())
+	})
+}
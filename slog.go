@@ -0,0 +1,207 @@
+//go:build go1.21
+
+package rec
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// SlogHandler is a `slog.Handler` backed by `*rec.Logger`.
+//
+// For example, for the following `*slog.Logger`:
+//
+//	l := slog.New(rec.NewSlogHandler(rec.Must(rec.New(os.Stderr))))
+//	l.Info("rec", slog.String("field", "added"))
+//
+// This will output a log like the following:
+//
+//	$ go run main.go
+//	{"timestamp":"...","severity":"INFO","caller":"...","message":"rec","field":"added"}
+//
+// If the time of `slog.Record` is zero, the timestamp field is omitted. If the PC of `slog.Record` is zero, the caller field is omitted.
+//
+// WithAttrs and WithGroup are encoded in advance by With and WithNamespace of `*rec.Logger`, so the groups without fields are omitted.
+// Note that the trace fields and the fields that ctx has are nested in the groups as well as the attributes of `slog.Record`.
+type SlogHandler struct {
+	l *Logger
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler returns `*rec.SlogHandler` that writes log entries through the passed `*rec.Logger`.
+func NewSlogHandler(l *Logger) *SlogHandler {
+	return &SlogHandler{
+		l: l,
+	}
+}
+
+// SlogLevelToSeverity converts `slog.Level` to `rec.Severity`.
+//
+//	slog.LevelDebug    and below -> rec.DEBUG
+//	slog.LevelInfo     .. +1     -> rec.INFO
+//	slog.LevelInfo+2   .. +3     -> rec.NOTICE
+//	slog.LevelWarn     .. +3     -> rec.WARNING
+//	slog.LevelError    .. +3     -> rec.ERROR
+//	slog.LevelError+4  .. +7     -> rec.CRITICAL
+//	slog.LevelError+8  .. +11    -> rec.ALERT
+//	slog.LevelError+12 and above -> rec.EMERGENCY
+func SlogLevelToSeverity(level slog.Level) Severity {
+	const step = 4
+
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelInfo+step/2:
+		return INFO
+	case level < slog.LevelWarn:
+		return NOTICE
+	case level < slog.LevelError:
+		return WARNING
+	case level < slog.LevelError+step:
+		return ERROR
+	case level < slog.LevelError+step*2:
+		return CRITICAL
+	case level < slog.LevelError+step*3:
+		return ALERT
+	default:
+		return EMERGENCY
+	}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return SlogLevelToSeverity(level) >= h.l.severityThreshold()
+}
+
+// Handle writes the `slog.Record` as a log entry of `*rec.Logger`, with the trace fields and rec.Fields that ctx has by ContextWithFields.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error { // nolint: gocritic
+	severity := SlogLevelToSeverity(r.Level)
+	if severity < h.l.severityThreshold() {
+		return nil
+	}
+
+	// NOTE: if `config.Clock` is set, it takes precedence over the time of the `slog.Record` for reproducible output.
+	now := r.Time
+	if h.l.config.Clock != nil {
+		now = h.l.config.Clock.Now()
+	}
+
+	if h.l.sampler != nil && !h.l.sampler.allow(now, severity, r.Message) {
+		return nil
+	}
+
+	// NOTE: if r.PC is zero, frame is zero and the caller field is omitted.
+	var frame runtime.Frame
+	if h.l.config.UseCallerField && r.PC != 0 {
+		frame, _ = runtime.CallersFrames([]uintptr{r.PC}).Next()
+	}
+
+	attrs := make([]Field, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendSlogField(attrs, a)
+
+		return true
+	})
+
+	fields := append(h.l.appendContextFields(ctx, severity, make([]Field, 0, len(attrs))), attrs...)
+
+	h.l.writeEntry(now, severity, frame, r.Message, fields)
+
+	return nil
+}
+
+// WithAttrs returns a new `*rec.SlogHandler` whose attributes consists of both the receiver's attributes and the arguments.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	fields := make([]Field, 0, len(attrs))
+	for _, a := range attrs {
+		fields = appendSlogField(fields, a)
+	}
+
+	if len(fields) == 0 {
+		return h
+	}
+
+	return &SlogHandler{
+		l: h.l.With(fields...),
+	}
+}
+
+// WithGroup returns a new `*rec.SlogHandler` with the given group appended to the receiver's existing groups.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &SlogHandler{
+		l: h.l.WithNamespace(name),
+	}
+}
+
+// appendSlogField converts `slog.Attr` to `rec.Field` and appends it to fields.
+func appendSlogField(fields []Field, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		return append(fields, slogAttrToField(a))
+	}
+
+	attrs := a.Value.Group()
+
+	// inline group
+	if a.Key == "" {
+		for _, attr := range attrs {
+			fields = appendSlogField(fields, attr)
+		}
+
+		return fields
+	}
+
+	group := make([]Field, 0, len(attrs))
+	for _, attr := range attrs {
+		group = appendSlogField(group, attr)
+	}
+
+	if len(group) == 0 {
+		return fields
+	}
+
+	return append(fields, Group(a.Key, group...))
+}
+
+// slogAttrToField converts `slog.Attr` that is not a group to `rec.Field`.
+func slogAttrToField(a slog.Attr) Field {
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return String(a.Key, a.Value.String())
+	case slog.KindInt64:
+		return Int64(a.Key, a.Value.Int64())
+	case slog.KindUint64:
+		return Uint64(a.Key, a.Value.Uint64())
+	case slog.KindFloat64:
+		return Float64(a.Key, a.Value.Float64())
+	case slog.KindBool:
+		return Bool(a.Key, a.Value.Bool())
+	case slog.KindDuration:
+		return Duration(a.Key, time.Nanosecond, a.Value.Duration())
+	case slog.KindTime:
+		return Time(a.Key, a.Value.Time())
+	case slog.KindAny, slog.KindGroup, slog.KindLogValuer:
+	}
+
+	if err, ok := a.Value.Any().(error); ok {
+		return ErrorWithKey(a.Key, err)
+	}
+
+	return Object(a.Key, a.Value.Any())
+}
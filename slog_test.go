@@ -0,0 +1,214 @@
+//go:build go1.21
+
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+type testLogValuer struct{}
+
+func (testLogValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("resolved", "value"))
+}
+
+func TestSlogLevelToSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		level  slog.Level
+		expect Severity
+	}{
+		{"success(Debug-4)", slog.LevelDebug - 4, DEBUG},
+		{"success(Debug)", slog.LevelDebug, DEBUG},
+		{"success(Info)", slog.LevelInfo, INFO},
+		{"success(Info+2)", slog.LevelInfo + 2, NOTICE},
+		{"success(Warn)", slog.LevelWarn, WARNING},
+		{"success(Error)", slog.LevelError, ERROR},
+		{"success(Error+4)", slog.LevelError + 4, CRITICAL},
+		{"success(Error+8)", slog.LevelError + 8, ALERT},
+		{"success(Error+12)", slog.LevelError + 12, EMERGENCY},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := SlogLevelToSeverity(tt.level)
+			FailIfNotEqual(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestSlogHandler_Enabled(t *testing.T) {
+	t.Parallel()
+
+	h := NewSlogHandler(Must(New(devnull, WithSeverityThreshold(WARNING))))
+
+	FailIfNotEqual(t, false, h.Enabled(context.Background(), slog.LevelInfo))
+	FailIfNotEqual(t, true, h.Enabled(context.Background(), slog.LevelWarn))
+}
+
+func TestSlogHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Attrs)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := slog.New(NewSlogHandler(Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))))
+
+		l.Info("test",
+			slog.String("string", "value"),
+			slog.Int("int", -1),
+			slog.Uint64("uint64", 1),
+			slog.Float64("float64", 1.5),
+			slog.Bool("bool", true),
+			slog.Duration("duration", time.Second),
+			slog.Any("error", errForTest),
+			slog.Any("object", map[string]int{"a": 1}),
+			slog.Any("valuer", testLogValuer{}),
+			slog.Group("group", slog.String("a", "b"), slog.Group("nested", slog.Int("c", 1)), slog.Group("empty")),
+			slog.Group("", slog.String("inline", "value")),
+			slog.Attr{},
+		)
+
+		const expect = `{"severity":"INFO","message":"test","string":"value","int":-1,"uint64":1,"float64":1.5,"bool":true,"duration":1000000000,"error":"test error","object":{"a":1},"valuer":{"resolved":"value"},"group":{"a":"b","nested":{"c":1}},"inline":"value"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(Clock)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := slog.New(NewSlogHandler(Must(New(buf, WithUseCallerField(false), WithClock(NewFixedClock(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)))))))
+
+		l.Info("test")
+
+		const expect = `{"timestamp":"2023-01-02T03:04:05Z","severity":"INFO","message":"test"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(WithAttrs,WithGroup)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := slog.New(NewSlogHandler(Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))))
+
+		l.With("top", 1).WithGroup("a").With("x", 1).WithGroup("b").With("y", 2).WithGroup("").Warn("test", "z", 3)
+
+		const expect = `{"severity":"WARNING","message":"test","top":1,"a":{"x":1,"b":{"y":2,"z":3}}}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(ContextWithFields)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := slog.New(NewSlogHandler(Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false)))))
+
+		l.InfoContext(ContextWithFields(context.Background(), String("requestId", "abc")), "test", "key", 1)
+
+		const expect = `{"severity":"INFO","message":"test","requestId":"abc","key":1}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(TraceExtractor)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := slog.New(NewSlogHandler(Must(New(buf, WithUseTimestampField(false), WithUseCallerField(false), WithTraceExtractor(testTraceExtractor), WithTraceFieldKeys("trace_id", "", "")))))
+
+		l.InfoContext(context.WithValue(context.Background(), testTraceKey{}, Trace{TraceID: "abc"}), "test")
+
+		const expect = `{"severity":"INFO","message":"test","trace_id":"abc"}` + defaultLineSeparator
+		actual := buf.String()
+		FailIfNotEqual(t, expect, actual)
+	})
+
+	t.Run("success(SeverityThreshold)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		h := NewSlogHandler(Must(New(buf, WithSeverityThreshold(ERROR))))
+
+		if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0)); err != nil {
+			t.Errorf("Handle: %v", err)
+		}
+
+		FailIfNotEqual(t, "", buf.String())
+	})
+
+	t.Run("success(Caller)", func(t *testing.T) {
+		t.Parallel()
+
+		buf := bytes.NewBuffer(nil)
+		l := slog.New(NewSlogHandler(Must(New(buf))))
+
+		l.Error("test", "error", errors.New("slog")) // <-
+		_, _, linenum, _ := runtime.Caller(0)        // <-
+		linenum--                                    // <- Get the number of lines executed by `l.Error()`.
+
+		expect := regexp.MustCompile(`^{"timestamp":"[0-9T:\.\+\-Z]+","severity":"ERROR","caller":"[^"]+:` + strconv.Itoa(linenum) + `","message":"test","error":"slog"}` + defaultLineSeparator)
+		actual := buf.String()
+		FailIfNotRegexpMatchString(t, expect, actual)
+	})
+}
+
+func TestSlogHandler_slogtest(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.NewBuffer(nil)
+	h := NewSlogHandler(Must(New(buf, WithTimestampFieldKey(slog.TimeKey), WithSeverityFieldKey(slog.LevelKey), WithMessageFieldKey(slog.MessageKey))))
+
+	results := func() []map[string]interface{} {
+		var ms []map[string]interface{}
+
+		for _, line := range bytes.Split(buf.Bytes(), []byte(defaultLineSeparator)) {
+			if len(line) == 0 {
+				continue
+			}
+
+			var m map[string]interface{}
+			if err := json.Unmarshal(line, &m); err != nil {
+				t.Fatalf("json.Unmarshal: %v: %s", err, line)
+			}
+
+			ms = append(ms, m)
+		}
+
+		return ms
+	}
+
+	FailIfNotErrorIs(t, nil, slogtest.TestHandler(h, results))
+}
+
+func TestSlogHandler_Handle_zero(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.NewBuffer(nil)
+	h := NewSlogHandler(Must(New(buf)))
+
+	FailIfNotErrorIs(t, nil, h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "zero", 0)))
+
+	const expect = `{"severity":"INFO","message":"zero"}` + defaultLineSeparator
+	actual := buf.String()
+	FailIfNotEqual(t, expect, actual)
+}
@@ -0,0 +1,690 @@
+package rec
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	syslogNilValue            = "-"
+	syslogVersion             = "1"
+	syslogRFC5424TimeFormat   = "2006-01-02T15:04:05.000000Z07:00"
+	syslogRFC3164TimeFormat   = "Jan _2 15:04:05"
+	syslogSDNameMaxLength     = 32
+	syslogHostnameMaxLength   = 255
+	syslogAppNameMaxLength    = 48
+	syslogProcIDMaxLength     = 128
+	syslogMsgIDMaxLength      = 32
+	defaultSyslogDialTimeout  = 5 * time.Second
+	defaultSyslogWriteTimeout = 5 * time.Second
+)
+
+// syslogLocalAddresses is the addresses of the local syslog daemon. cf. log/syslog
+// nolint: gochecknoglobals
+var syslogLocalAddresses = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogFormat is the message format of `*rec.SyslogWriter`.
+type SyslogFormat string
+
+const (
+	// SyslogFormatRFC5424 formats the message like `<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG`.
+	SyslogFormatRFC5424 SyslogFormat = "rfc5424"
+	// SyslogFormatRFC3164 formats the message like `<PRI>Mmm dd hh:mm:ss HOSTNAME APP-NAME[PROCID]: MSG`.
+	SyslogFormatRFC3164 SyslogFormat = "rfc3164"
+)
+
+// SyslogFacility is the facility of syslog. cf. https://datatracker.ietf.org/doc/html/rfc5424#section-6.2.1
+type SyslogFacility int
+
+// Syslog facilities.
+const (
+	SyslogFacilityKern SyslogFacility = iota
+	SyslogFacilityUser
+	SyslogFacilityMail
+	SyslogFacilityDaemon
+	SyslogFacilityAuth
+	SyslogFacilitySyslog
+	SyslogFacilityLPR
+	SyslogFacilityNews
+	SyslogFacilityUUCP
+	SyslogFacilityCron
+	SyslogFacilityAuthPriv
+	SyslogFacilityFTP
+	SyslogFacilityNTP
+	SyslogFacilityAudit
+	SyslogFacilityAlert
+	SyslogFacilityClock
+	SyslogFacilityLocal0
+	SyslogFacilityLocal1
+	SyslogFacilityLocal2
+	SyslogFacilityLocal3
+	SyslogFacilityLocal4
+	SyslogFacilityLocal5
+	SyslogFacilityLocal6
+	SyslogFacilityLocal7
+)
+
+// SyslogWriter is an io.Writer that sends log entries to the syslog daemon over UDP, TCP or unix socket.
+//
+// When `*rec.Logger` writes to `*rec.SyslogWriter`, the severity of the log entry is mapped to the syslog severity by (rec.Severity).Syslog,
+// and the log entry encoded in `config.Format` is sent as MSG. If WithSyslogStructuredData is set, the message is sent as MSG,
+// and the fields are sent as STRUCTURED-DATA.
+// TCP uses the octet-counting framing (RFC 6587). If sending fails, `*rec.SyslogWriter` reconnects and retries once.
+//
+// `*rec.SyslogWriter` is not wrapped with `*rec.AsyncWriter` even if `config.AsyncQueueSize` is set.
+type SyslogWriter struct {
+	mu     sync.Mutex
+	closed bool
+
+	network      string
+	address      string
+	dialTimeout  time.Duration
+	writeTimeout time.Duration
+	conn         net.Conn
+	framed       []byte
+
+	format           SyslogFormat
+	facility         SyslogFacility
+	hostname         string
+	appName          string
+	procID           string
+	msgID            string
+	structuredData   bool
+	structuredDataID string
+
+	now func() time.Time
+}
+
+// SyslogOption is a struct that handles the `*rec.SyslogWriter` used when new `*rec.SyslogWriter`.
+type SyslogOption struct {
+	name string
+	f    func(*SyslogWriter) error
+}
+
+// WithSyslogFormat returns `rec.SyslogOption` for setting the message format. Default is SyslogFormatRFC5424.
+func WithSyslogFormat(format SyslogFormat) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			switch format {
+			case SyslogFormatRFC5424, SyslogFormatRFC3164:
+			default:
+				return fmt.Errorf("format=%s: %w", format, ErrUnknownFormat)
+			}
+
+			w.format = format
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogFacility returns `rec.SyslogOption` for setting the facility (0-23). Default is SyslogFacilityUser.
+func WithSyslogFacility(facility SyslogFacility) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			if facility < SyslogFacilityKern || facility > SyslogFacilityLocal7 {
+				return fmt.Errorf("facility=%d: %w", facility, ErrInvalidSyslogFacility)
+			}
+
+			w.facility = facility
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogHostname returns `rec.SyslogOption` for setting HOSTNAME. Default is os.Hostname.
+func WithSyslogHostname(hostname string) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			w.hostname = hostname
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogAppName returns `rec.SyslogOption` for setting APP-NAME. Default is the base name of os.Args[0].
+func WithSyslogAppName(appName string) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			w.appName = appName
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogProcID returns `rec.SyslogOption` for setting PROCID. Default is os.Getpid.
+func WithSyslogProcID(procID string) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			w.procID = procID
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogMsgID returns `rec.SyslogOption` for setting MSGID. Default is `-`. It is ignored in SyslogFormatRFC3164.
+func WithSyslogMsgID(msgID string) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			w.msgID = msgID
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogStructuredData returns `rec.SyslogOption` for sending the fields as STRUCTURED-DATA like `[SD-ID key="value"]` instead of MSG.
+// sdID is required, and it should be `name@<private enterprise number>` registered with IANA. It is ignored in SyslogFormatRFC3164.
+func WithSyslogStructuredData(sdID string) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			if sdID == "" {
+				return fmt.Errorf("sdID %w", ErrIsEmpty)
+			}
+
+			w.structuredData = true
+			w.structuredDataID = sdID
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogDialTimeout returns `rec.SyslogOption` for setting the timeout to connect to the syslog daemon. Default is 5s.
+func WithSyslogDialTimeout(timeout time.Duration) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			w.dialTimeout = timeout
+
+			return nil
+		},
+	}
+}
+
+// WithSyslogWriteTimeout returns `rec.SyslogOption` for setting the timeout to send a message to the syslog daemon. Default is 5s.
+// If timeout is 0 or less, sending a message may block forever, e.g. when the TCP connection is stalled.
+func WithSyslogWriteTimeout(timeout time.Duration) SyslogOption {
+	return SyslogOption{
+		name: funcName(),
+		f: func(w *SyslogWriter) error {
+			w.writeTimeout = timeout
+
+			return nil
+		},
+	}
+}
+
+// NewSyslogWriter connects to the syslog daemon and returns `*rec.SyslogWriter`.
+//
+// network is `udp`, `tcp`, `unix` or `unixgram` (and their variants such as `tcp4`).
+// If network and address are empty, NewSyslogWriter connects to the local syslog daemon such as `/dev/log`.
+// HOSTNAME, APP-NAME, PROCID and MSGID are truncated to the max length of RFC 5424, and the characters other than printable US-ASCII are replaced with `_`.
+func NewSyslogWriter(network, address string, options ...SyslogOption) (*SyslogWriter, error) {
+	w := &SyslogWriter{
+		network:      network,
+		address:      address,
+		dialTimeout:  defaultSyslogDialTimeout,
+		writeTimeout: defaultSyslogWriteTimeout,
+		format:       SyslogFormatRFC5424,
+		facility:     SyslogFacilityUser,
+		appName:      filepath.Base(os.Args[0]),
+		procID:       strconv.Itoa(os.Getpid()),
+		msgID:        syslogNilValue,
+		now:          time.Now,
+	}
+
+	var err error
+
+	w.hostname, err = os.Hostname()
+	if err != nil {
+		w.hostname = defaultHostnameFieldValue
+	}
+
+	for _, opt := range options {
+		if err := opt.f(w); err != nil {
+			return nil, fmt.Errorf("%s: %w", opt.name, err)
+		}
+	}
+
+	w.hostname = sanitizeSyslogHeaderField(w.hostname, syslogHostnameMaxLength)
+	w.appName = sanitizeSyslogHeaderField(w.appName, syslogAppNameMaxLength)
+	w.procID = sanitizeSyslogHeaderField(w.procID, syslogProcIDMaxLength)
+	w.msgID = sanitizeSyslogHeaderField(w.msgID, syslogMsgIDMaxLength)
+
+	if err := w.connect(); err != nil {
+		return nil, fmt.Errorf("(*rec.SyslogWriter).connect: %w", err)
+	}
+
+	return w, nil
+}
+
+func (w *SyslogWriter) connect() error {
+	if w.network != "" || w.address != "" {
+		conn, err := net.DialTimeout(w.network, w.address, w.dialTimeout)
+		if err != nil {
+			return fmt.Errorf("net.DialTimeout: %w", err)
+		}
+
+		w.conn = conn
+
+		return nil
+	}
+
+	var err error
+
+	for _, address := range syslogLocalAddresses {
+		for _, network := range [...]string{"unixgram", "unix"} {
+			var conn net.Conn
+
+			conn, err = net.DialTimeout(network, address, w.dialTimeout)
+			if err == nil {
+				w.network, w.address, w.conn = network, address, conn
+
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("local syslog daemon: net.DialTimeout: %w", err)
+}
+
+// Write sends p as MSG with the syslog severity Informational. The trailing newline of p is removed.
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	b := bufferPool.Get().(*buffer) // nolint: forcetypeassert
+	defer bufferPool.Put(b)
+
+	b.Buffer = w.appendHeader(b.Buffer[:0], w.now(), INFO)
+	if w.format == SyslogFormatRFC5424 {
+		b.Buffer = append(b.Buffer, syslogNilValue+" "...)
+	}
+
+	msg := p
+	for len(msg) > 0 && (msg[len(msg)-1] == '\n' || msg[len(msg)-1] == '\r') {
+		msg = msg[:len(msg)-1]
+	}
+
+	b.Buffer = append(b.Buffer, msg...)
+
+	if err := w.send(b.Buffer); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close closes the connection to the syslog daemon. After Close, Write returns os.ErrClosed.
+func (w *SyslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+
+	if w.conn == nil {
+		return nil
+	}
+
+	if err := w.conn.Close(); err != nil {
+		return fmt.Errorf("(net.Conn).Close: %w", err)
+	}
+
+	return nil
+}
+
+// send sends the syslog message. If it fails, send reconnects and retries once.
+func (w *SyslogWriter) send(msg []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return fmt.Errorf("(*rec.SyslogWriter).send: %w", os.ErrClosed)
+	}
+
+	if w.conn != nil {
+		if err := w.writeConn(msg); err == nil {
+			return nil
+		}
+
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	if err := w.connect(); err != nil {
+		return fmt.Errorf("(*rec.SyslogWriter).connect: %w", err)
+	}
+
+	if err := w.writeConn(msg); err != nil {
+		return fmt.Errorf("(*rec.SyslogWriter).writeConn: %w", err)
+	}
+
+	return nil
+}
+
+func (w *SyslogWriter) writeConn(msg []byte) error {
+	const base = 10
+
+	// NOTE: send holds the lock while writing, so a stalled connection must not block the other goroutines forever.
+	if w.writeTimeout > 0 {
+		if err := w.conn.SetWriteDeadline(time.Now().Add(w.writeTimeout)); err != nil {
+			return fmt.Errorf("(net.Conn).SetWriteDeadline: %w", err)
+		}
+	}
+
+	var err error
+
+	switch w.network {
+	case "tcp", "tcp4", "tcp6":
+		// MSG-LEN SP SYSLOG-MSG. cf. https://datatracker.ietf.org/doc/html/rfc6587#section-3.4.1
+		w.framed = append(strconv.AppendInt(w.framed[:0], int64(len(msg)), base), ' ')
+		w.framed = append(w.framed, msg...)
+		_, err = w.conn.Write(w.framed)
+	case "unix":
+		w.framed = append(append(w.framed[:0], msg...), '\n')
+		_, err = w.conn.Write(w.framed)
+	default:
+		_, err = w.conn.Write(msg)
+	}
+
+	if err != nil {
+		return fmt.Errorf("(net.Conn).Write: %w", err)
+	}
+
+	return nil
+}
+
+// appendHeader appends the syslog header before STRUCTURED-DATA in RFC 5424, or before MSG in RFC 3164.
+func (w *SyslogWriter) appendHeader(dst []byte, now time.Time, severity Severity) []byte {
+	const base = 10
+
+	// NOTE: the timestamp is required in the header, e.g. `slog.Record` without time.
+	if now.IsZero() {
+		now = w.now()
+	}
+
+	// <PRI>
+	dst = append(dst, '<')
+	dst = strconv.AppendInt(dst, int64(int(w.facility)*8+severity.Syslog()), base) // nolint: gomnd
+	dst = append(dst, '>')
+
+	if w.format == SyslogFormatRFC3164 {
+		// <PRI>Mmm dd hh:mm:ss HOSTNAME APP-NAME[PROCID]:
+		dst = now.AppendFormat(dst, syslogRFC3164TimeFormat)
+		dst = append(append(dst, ' '), w.hostname...)
+		dst = append(append(dst, ' '), w.appName...)
+		dst = append(append(append(dst, '['), w.procID...), "]: "...)
+
+		return dst
+	}
+
+	// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID
+	dst = append(dst, syslogVersion+" "...)
+	dst = now.AppendFormat(dst, syslogRFC5424TimeFormat)
+	dst = appendSyslogHeaderField(dst, w.hostname)
+	dst = appendSyslogHeaderField(dst, w.appName)
+	dst = appendSyslogHeaderField(dst, w.procID)
+	dst = appendSyslogHeaderField(dst, w.msgID)
+
+	return append(dst, ' ')
+}
+
+// sanitizeSyslogHeaderField replaces the characters other than PRINTUSASCII (%d33-126) with `_`, and truncates value to maxLength.
+// If value is empty, `-` is returned. cf. https://datatracker.ietf.org/doc/html/rfc5424#section-6
+func sanitizeSyslogHeaderField(value string, maxLength int) string {
+	if value == "" {
+		return syslogNilValue
+	}
+
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+
+	b := []byte(value)
+	for i := range b {
+		if b[i] <= ' ' || b[i] >= 0x7f {
+			b[i] = '_'
+		}
+	}
+
+	return string(b)
+}
+
+// appendSyslogHeaderField appends ` value`. If value is empty, `-` is appended.
+func appendSyslogHeaderField(dst []byte, value string) []byte {
+	if value == "" {
+		value = syslogNilValue
+	}
+
+	return append(append(dst, ' '), value...)
+}
+
+// writeSyslog encodes the log entry as the syslog message, and sends it.
+func (l *Logger) writeSyslog(w *SyslogWriter, now time.Time, severity Severity, frame runtime.Frame, message string, fields []Field) {
+	b := bufferPool.Get().(*buffer) // nolint: forcetypeassert
+	defer bufferPool.Put(b)
+
+	b.Buffer = w.appendHeader(b.Buffer[:0], now, severity)
+
+	switch {
+	case w.format == SyslogFormatRFC5424 && w.structuredData:
+		b.Buffer = l.appendSyslogStructuredData(b.Buffer, w.structuredDataID, fields)
+		b.Buffer = append(append(b.Buffer, ' '), l.config.Redactor.maskString(message)...)
+	default:
+		if w.format == SyslogFormatRFC5424 {
+			b.Buffer = append(b.Buffer, syslogNilValue+" "...)
+		}
+
+		b.Buffer = l.appendEntry(b.Buffer, l.config.Format, now, severity, frame, message, fields)
+		b.Buffer = b.Buffer[:len(b.Buffer)-len(l.config.LineSeparator)]
+	}
+
+	if err := w.send(b.Buffer); err != nil {
+		if defaultLogger.writer == w {
+			// NOTE: avoid recursion.
+			return
+		}
+
+		err = fmt.Errorf("(*rec.Logger).write: writer=%#v: %w", w, err)
+		defaultLogger.writeAt(now, ERROR, err.Error(), Error(err))
+	}
+}
+
+// appendSyslogStructuredData appends the context fields and fields as `[SD-ID key="value" ...]`. If there are no fields, `-` is appended.
+func (l *Logger) appendSyslogStructuredData(dst []byte, sdID string, fields []Field) []byte {
+	if len(l.contextFieldList) == 0 && len(fields) == 0 {
+		return append(dst, syslogNilValue...)
+	}
+
+	dst = append(dst, '[')
+	dst = appendSyslogSDName(dst, "", sdID)
+
+	for i := range l.contextFieldList {
+		field, ok := l.sensitiveField(l.contextFieldList[i])
+		if !ok {
+			continue
+		}
+
+		dst = appendSyslogSDParam(dst, "", field, l.config.Redactor)
+	}
+
+	for i := range fields {
+		field, ok := l.sensitiveField(fields[i])
+		if !ok {
+			continue
+		}
+
+		dst = appendSyslogSDParam(dst, l.namespacePrefix, field, l.config.Redactor)
+	}
+
+	return append(dst, ']')
+}
+
+// appendSyslogSDName appends SD-NAME. The characters that cannot be used in SD-NAME are replaced with `_`, and it is truncated to 32 characters.
+func appendSyslogSDName(dst []byte, prefix, name string) []byte {
+	n := 0
+
+	for _, s := range [...]string{prefix, name} {
+		for i := 0; i < len(s) && n < syslogSDNameMaxLength; i++ {
+			c := s[i]
+			if c <= ' ' || c >= 0x7f || c == '=' || c == ']' || c == '"' {
+				c = '_'
+			}
+
+			dst = append(dst, c)
+			n++
+		}
+	}
+
+	return dst
+}
+
+// appendSyslogSDParam appends ` key="value"`. The value is rendered in the same way as JSON, and JSON string is unquoted.
+func appendSyslogSDParam(dst []byte, prefix string, f Field, redactor *Redactor) []byte {
+	dst = append(dst, ' ')
+	dst = appendSyslogSDName(dst, prefix, f.key)
+	dst = append(dst, `="`...)
+
+	start := len(dst)
+	dst = appendFieldValue(dst, f, json.Marshal)
+	dst = redactor.redactValue(dst, start, f.key)
+
+	// NOTE: the value is appended after the JSON, and moved to the place of the JSON, so that no buffer is allocated.
+	end := len(dst)
+	dst = appendSyslogParamValue(dst, dst[start:end])
+	dst = dst[:start+copy(dst[start:], dst[end:])]
+
+	return append(dst, '"')
+}
+
+// appendSyslogParamValue appends value rendered as JSON as PARAM-VALUE. JSON string is unquoted and unescaped.
+func appendSyslogParamValue(dst, value []byte) []byte {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		for i := range value {
+			dst = appendSyslogParamByte(dst, value[i])
+		}
+
+		return dst
+	}
+
+	value = value[1 : len(value)-1]
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			dst = appendSyslogParamByte(dst, value[i])
+
+			continue
+		}
+
+		i++
+
+		switch value[i] {
+		case 'b':
+			dst = append(dst, '\b')
+		case 'f':
+			dst = append(dst, '\f')
+		case 'n':
+			dst = append(dst, '\n')
+		case 'r':
+			dst = append(dst, '\r')
+		case 't':
+			dst = append(dst, '\t')
+		case 'u':
+			r, n := decodeJSONUnicodeEscapeSequence(value[i-1:])
+			dst = appendSyslogParamRune(dst, r)
+			i += n - 2
+		default: // `"`, `\` and `/`
+			dst = appendSyslogParamByte(dst, value[i])
+		}
+	}
+
+	return dst
+}
+
+// appendSyslogParamByte appends c. PARAM-VALUE escapes `"`, `\` and `]`. cf. https://datatracker.ietf.org/doc/html/rfc5424#section-6.3.3
+func appendSyslogParamByte(dst []byte, c byte) []byte {
+	switch c {
+	case '"', '\\', ']':
+		dst = append(dst, '\\')
+	}
+
+	return append(dst, c)
+}
+
+// appendSyslogParamRune appends r encoded in UTF-8 in the same way as appendSyslogParamByte.
+func appendSyslogParamRune(dst []byte, r rune) []byte {
+	if r < utf8.RuneSelf {
+		return appendSyslogParamByte(dst, byte(r))
+	}
+
+	var b [utf8.UTFMax]byte
+
+	return append(dst, b[:utf8.EncodeRune(b[:], r)]...)
+}
+
+// decodeJSONUnicodeEscapeSequence decodes `\uXXXX` or the surrogate pair `\uXXXX\uXXXX` at the beginning of b,
+// and returns the rune and the number of bytes consumed. The invalid sequence is decoded as utf8.RuneError.
+func decodeJSONUnicodeEscapeSequence(b []byte) (rune, int) {
+	r1, ok := parseJSONHex4(b)
+	if !ok {
+		return utf8.RuneError, 2
+	}
+
+	if utf16.IsSurrogate(r1) {
+		if r2, ok := parseJSONHex4(b[6:]); ok {
+			if r := utf16.DecodeRune(r1, r2); r != utf8.RuneError {
+				return r, 12
+			}
+		}
+
+		return utf8.RuneError, 6
+	}
+
+	return r1, 6
+}
+
+// parseJSONHex4 parses `\uXXXX` at the beginning of b.
+func parseJSONHex4(b []byte) (rune, bool) {
+	if len(b) < 6 || b[0] != '\\' || b[1] != 'u' {
+		return 0, false
+	}
+
+	var r rune
+
+	for _, c := range b[2:6] {
+		switch {
+		case '0' <= c && c <= '9':
+			r = r<<4 | rune(c-'0')
+		case 'a' <= c && c <= 'f':
+			r = r<<4 | rune(c-'a'+10)
+		case 'A' <= c && c <= 'F':
+			r = r<<4 | rune(c-'A'+10)
+		default:
+			return 0, false
+		}
+	}
+
+	return r, true
+}
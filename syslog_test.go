@@ -0,0 +1,326 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSyslogTime = time.Date(2023, 1, 2, 3, 4, 5, 678000000, time.UTC)
+
+func newTestSyslogUDPListener(t *testing.T) net.PacketConn {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	FailIfNotErrorIs(t, nil, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func readTestSyslogPacket(t *testing.T, conn net.PacketConn) string {
+	t.Helper()
+
+	b := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(b)
+	FailIfNotErrorIs(t, nil, err)
+
+	return string(b[:n])
+}
+
+// readTestSyslogFrame reads `MSG-LEN SP SYSLOG-MSG`.
+func readTestSyslogFrame(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	length, err := r.ReadString(' ')
+	FailIfNotErrorIs(t, nil, err)
+	n, err := strconv.Atoi(length[:len(length)-1])
+	FailIfNotErrorIs(t, nil, err)
+
+	b := make([]byte, n)
+	_, err = io.ReadFull(r, b)
+	FailIfNotErrorIs(t, nil, err)
+
+	return string(b)
+}
+
+func TestNewSyslogWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error(Option)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSyslogWriter("udp", "127.0.0.1:0", WithSyslogFacility(24))
+		FailIfNotErrorIs(t, ErrInvalidSyslogFacility, err)
+
+		_, err = NewSyslogWriter("udp", "127.0.0.1:0", WithSyslogFormat("unknown"))
+		FailIfNotErrorIs(t, ErrUnknownFormat, err)
+
+		_, err = NewSyslogWriter("udp", "127.0.0.1:0", WithSyslogStructuredData(""))
+		FailIfNotErrorIs(t, ErrIsEmpty, err)
+	})
+
+	t.Run("error(connect)", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSyslogWriter("unix", filepath.Join(t.TempDir(), "notfound.sock"))
+		FailIfErrorIs(t, nil, err)
+	})
+}
+
+func TestSyslogWriter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(UDP,RFC5424)", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSyslogUDPListener(t)
+		w, err := NewSyslogWriter("udp", server.LocalAddr().String(), WithSyslogFacility(SyslogFacilityLocal0), WithSyslogHostname("host"), WithSyslogAppName("app"), WithSyslogProcID("123"), WithSyslogMsgID("msg"))
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+		l.writeAt(testSyslogTime, WARNING, "test", String("key", "value"))
+
+		// <PRI> = 16 (local0) * 8 + 4 (warning)
+		const expect = `<132>1 2023-01-02T03:04:05.678000Z host app 123 msg - {"severity":"WARNING","message":"test","key":"value"}`
+		FailIfNotEqual(t, expect, readTestSyslogPacket(t, server))
+	})
+
+	t.Run("success(UDP,RFC3164,Write)", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSyslogUDPListener(t)
+		w, err := NewSyslogWriter("udp", server.LocalAddr().String(), WithSyslogFormat(SyslogFormatRFC3164), WithSyslogHostname("host"), WithSyslogAppName("app"), WithSyslogProcID("123"))
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+		w.now = func() time.Time { return testSyslogTime }
+
+		n, err := w.Write([]byte("test\n"))
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotEqual(t, 5, n)
+
+		// <PRI> = 1 (user) * 8 + 6 (informational)
+		const expect = `<14>Jan  2 03:04:05 host app[123]: test`
+		FailIfNotEqual(t, expect, readTestSyslogPacket(t, server))
+	})
+
+	t.Run("success(UDP,StructuredData)", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSyslogUDPListener(t)
+		w, err := NewSyslogWriter("udp", server.LocalAddr().String(), WithSyslogStructuredData("app@12345"), WithSyslogHostname(""), WithSyslogAppName("app"), WithSyslogProcID("123"))
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+
+		l := Must(New(w))
+		l.writeAt(testSyslogTime, ERROR, "no fields")
+		FailIfNotEqual(t, `<11>1 2023-01-02T03:04:05.678000Z - app 123 - - no fields`, readTestSyslogPacket(t, server))
+
+		l.With(String("context", "value")).writeAt(testSyslogTime, ERROR, "test", String("quote", `"a\b]`), Int("int", 1), Error(nil), String("key with space=", "v"))
+		const expect = `<11>1 2023-01-02T03:04:05.678000Z - app 123 - [app@12345 context="value" quote="\"a\\b\]" int="1" error="null" key_with_space_="v"] test`
+		FailIfNotEqual(t, expect, readTestSyslogPacket(t, server))
+	})
+
+	t.Run("success(TCP,OctetCounting,Reconnect)", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		FailIfNotErrorIs(t, nil, err)
+		defer listener.Close()
+
+		w, err := NewSyslogWriter("tcp", listener.Addr().String(), WithSyslogHostname("host"), WithSyslogAppName("app"), WithSyslogProcID("123"))
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+
+		conn, err := listener.Accept()
+		FailIfNotErrorIs(t, nil, err)
+		defer conn.Close()
+
+		l.writeAt(testSyslogTime, INFO, "first")
+		l.writeAt(testSyslogTime, INFO, "second")
+
+		r := bufio.NewReader(conn)
+		FailIfNotEqual(t, `<14>1 2023-01-02T03:04:05.678000Z host app 123 - - {"severity":"INFO","message":"first"}`, readTestSyslogFrame(t, r))
+		FailIfNotEqual(t, `<14>1 2023-01-02T03:04:05.678000Z host app 123 - - {"severity":"INFO","message":"second"}`, readTestSyslogFrame(t, r))
+
+		// NOTE: break the connection to reconnect.
+		w.mu.Lock()
+		_ = w.conn.Close()
+		w.mu.Unlock()
+
+		l.writeAt(testSyslogTime, INFO, "reconnected")
+
+		reconnected, err := listener.Accept()
+		FailIfNotErrorIs(t, nil, err)
+		defer reconnected.Close()
+
+		FailIfNotEqual(t, `<14>1 2023-01-02T03:04:05.678000Z host app 123 - - {"severity":"INFO","message":"reconnected"}`, readTestSyslogFrame(t, bufio.NewReader(reconnected)))
+	})
+
+	t.Run("success(UDP,SanitizeHeader)", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSyslogUDPListener(t)
+		w, err := NewSyslogWriter("udp", server.LocalAddr().String(), WithSyslogHostname("my host"), WithSyslogAppName(strings.Repeat("a", 50)), WithSyslogProcID(""), WithSyslogMsgID("msg\n"))
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+		l.writeAt(testSyslogTime, INFO, "test")
+
+		expect := `<14>1 2023-01-02T03:04:05.678000Z my_host ` + strings.Repeat("a", 48) + ` - msg_ - {"severity":"INFO","message":"test"}`
+		FailIfNotEqual(t, expect, readTestSyslogPacket(t, server))
+	})
+
+	t.Run("error(TCP,WriteTimeout)", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		FailIfNotErrorIs(t, nil, err)
+		defer listener.Close()
+
+		w, err := NewSyslogWriter("tcp", listener.Addr().String(), WithSyslogWriteTimeout(time.Nanosecond))
+		FailIfNotErrorIs(t, nil, err)
+		defer w.Close()
+
+		// NOTE: the deadline has passed before writing, so the write times out without a stalled server.
+		_, err = w.Write([]byte("test"))
+		var netErr net.Error
+		FailIfNotEqual(t, true, errors.As(err, &netErr) && netErr.Timeout())
+	})
+
+	t.Run("success(MultiSinkWriter)", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSyslogUDPListener(t)
+		syslogWriter, err := NewSyslogWriter("udp", server.LocalAddr().String(), WithSyslogHostname("host"), WithSyslogAppName("app"), WithSyslogProcID("123"))
+		FailIfNotErrorIs(t, nil, err)
+		defer syslogWriter.Close()
+
+		buf := bytes.NewBuffer(nil)
+		w, err := NewMultiSinkWriter(Sink{Writer: syslogWriter, SeverityThreshold: ERROR}, Sink{Writer: buf})
+		FailIfNotErrorIs(t, nil, err)
+
+		l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+		l.writeAt(testSyslogTime, INFO, "info")
+		l.writeAt(testSyslogTime, CRITICAL, "critical")
+
+		FailIfNotEqual(t, `<10>1 2023-01-02T03:04:05.678000Z host app 123 - - {"severity":"CRITICAL","message":"critical"}`, readTestSyslogPacket(t, server))
+		FailIfNotEqual(t, `{"severity":"INFO","message":"info"}`+defaultLineSeparator+`{"severity":"CRITICAL","message":"critical"}`+defaultLineSeparator, buf.String())
+	})
+
+	t.Run("error(Closed)", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSyslogUDPListener(t)
+		w, err := NewSyslogWriter("udp", server.LocalAddr().String())
+		FailIfNotErrorIs(t, nil, err)
+		FailIfNotErrorIs(t, nil, w.Close())
+		FailIfNotErrorIs(t, nil, w.Close())
+
+		_, err = w.Write([]byte("test"))
+		FailIfNotErrorIs(t, os.ErrClosed, err)
+	})
+}
+
+// nolint: paralleltest
+func TestSyslogWriter_local(t *testing.T) {
+	// NOTE: the path of unix socket must be short.
+	dir, err := os.MkdirTemp("", "rec")
+	FailIfNotErrorIs(t, nil, err)
+	defer os.RemoveAll(dir)
+
+	address := filepath.Join(dir, "log")
+	server, err := net.ListenPacket("unixgram", address)
+	FailIfNotErrorIs(t, nil, err)
+	defer server.Close()
+
+	backup := syslogLocalAddresses
+	syslogLocalAddresses = []string{filepath.Join(dir, "notfound"), address}
+	defer func() { syslogLocalAddresses = backup }()
+
+	w, err := NewSyslogWriter("", "", WithSyslogHostname("host"), WithSyslogAppName("app"), WithSyslogProcID("123"))
+	FailIfNotErrorIs(t, nil, err)
+	defer w.Close()
+
+	l := Must(New(w, WithUseTimestampField(false), WithUseCallerField(false)))
+	l.writeAt(testSyslogTime, DEBUG, "test")
+
+	FailIfNotEqual(t, `<15>1 2023-01-02T03:04:05.678000Z host app 123 - - {"severity":"DEBUG","message":"test"}`, readTestSyslogPacket(t, server))
+
+	syslogLocalAddresses = []string{filepath.Join(dir, "notfound")}
+	_, err = NewSyslogWriter("", "")
+	FailIfErrorIs(t, nil, err)
+}
+
+func Test_sanitizeSyslogHeaderField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     string
+		maxLength int
+		expect    string
+	}{
+		{"success(ASCII)", "app-1.0", syslogAppNameMaxLength, "app-1.0"},
+		{"success(Empty)", "", syslogAppNameMaxLength, "-"},
+		{"success(Replace)", "my app\té", syslogAppNameMaxLength, "my_app___"},
+		{"success(Truncate)", strings.Repeat("a", 40), syslogMsgIDMaxLength, strings.Repeat("a", 32)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			FailIfNotEqual(t, tt.expect, sanitizeSyslogHeaderField(tt.value, tt.maxLength))
+		})
+	}
+}
+
+func Test_appendSyslogSDName(t *testing.T) {
+	t.Parallel()
+
+	actual := string(appendSyslogSDName(nil, "namespace.", "key=\"]é 0123456789012345678901234567890123456789"))
+	FailIfNotEqual(t, `namespace.key______0123456789012`, actual)
+	FailIfNotRegexpMatchString(t, regexp.MustCompile(`^.{32}$`), actual)
+}
+
+func Test_appendSyslogParamValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		value  string
+		expect string
+	}{
+		{"success(Number)", `1`, `1`},
+		{"success(Object)", `{"a":"]"}`, `{\"a\":\"\]\"}`},
+		{"success(String)", `"a]b"`, `a\]b`},
+		{"success(Escape)", `"\"\\\/\b\f\n\r\t"`, "\\\"\\\\/\b\f\n\r\t"},
+		{"success(Unicode)", `"\u0000\u005d\u00e9\u2028\ud83d\ude00"`, "\x00\\]\u00e9\u2028\U0001f600"},
+		{"success(InvalidUnicode)", `"\u00\ud83dx\ud83d\u0041"`, "\ufffd00\ufffdx\ufffdA"},
+		{"success(TrailingBackslash)", `"a\"`, `a\\`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			FailIfNotEqual(t, tt.expect, string(appendSyslogParamValue([]byte("prefix:"), []byte(tt.value))[len("prefix:"):]))
+		})
+	}
+}
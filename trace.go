@@ -0,0 +1,67 @@
+package rec
+
+import "context"
+
+const (
+	defaultTraceIDFieldKey      = "trace_id"
+	defaultSpanIDFieldKey       = "span_id"
+	defaultTraceSampledFieldKey = "trace_sampled"
+
+	cloudLoggingTraceIDFieldKey      = "logging.googleapis.com/trace"
+	cloudLoggingSpanIDFieldKey       = "logging.googleapis.com/spanId"
+	cloudLoggingTraceSampledFieldKey = "logging.googleapis.com/trace_sampled"
+)
+
+// Trace is the trace information extracted from context.Context by `rec.TraceExtractor`.
+type Trace struct {
+	TraceID string
+	SpanID  string
+	Sampled bool
+}
+
+// TraceExtractor extracts `rec.Trace` from context.Context.
+//
+// For example, with OpenTelemetry:
+//
+//	rec.TraceExtractorFunc(func(ctx context.Context) (rec.Trace, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		return rec.Trace{TraceID: sc.TraceID().String(), SpanID: sc.SpanID().String(), Sampled: sc.IsSampled()}, sc.IsValid()
+//	})
+type TraceExtractor interface {
+	// ExtractTrace returns `rec.Trace` and true if ctx has the trace information.
+	ExtractTrace(ctx context.Context) (Trace, bool)
+}
+
+// TraceExtractorFunc is an adapter to use the function as `rec.TraceExtractor`.
+type TraceExtractorFunc func(ctx context.Context) (Trace, bool)
+
+// ExtractTrace calls f(ctx).
+func (f TraceExtractorFunc) ExtractTrace(ctx context.Context) (Trace, bool) {
+	return f(ctx)
+}
+
+// appendTraceFields appends the trace fields extracted from ctx by `config.TraceExtractor`. The field that the key is empty is omitted.
+func (l *Logger) appendTraceFields(dst []Field, ctx context.Context) []Field { // nolint: revive
+	if l.config.TraceExtractor == nil || ctx == nil {
+		return dst
+	}
+
+	trace, ok := l.config.TraceExtractor.ExtractTrace(ctx)
+	if !ok {
+		return dst
+	}
+
+	if l.config.TraceIDFieldKey != "" {
+		dst = append(dst, String(l.config.TraceIDFieldKey, l.config.TraceIDFieldValuePrefix+trace.TraceID))
+	}
+
+	if l.config.SpanIDFieldKey != "" {
+		dst = append(dst, String(l.config.SpanIDFieldKey, trace.SpanID))
+	}
+
+	if l.config.TraceSampledFieldKey != "" {
+		dst = append(dst, Bool(l.config.TraceSampledFieldKey, trace.Sampled))
+	}
+
+	return dst
+}
@@ -0,0 +1,47 @@
+// nolint: testpackage
+package rec
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type testTraceKey struct{}
+
+var testTraceExtractor = TraceExtractorFunc(func(ctx context.Context) (Trace, bool) { // nolint: gochecknoglobals
+	trace, ok := ctx.Value(testTraceKey{}).(Trace)
+
+	return trace, ok
+})
+
+func TestLogger_appendTraceFields(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextWithFields(context.WithValue(context.Background(), testTraceKey{}, Trace{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}), String("requestId", "abc"))
+
+	tests := []struct {
+		name    string
+		options []Option
+		ctx     context.Context
+		expect  string
+	}{
+		{"success(Default)", []Option{WithTraceExtractor(testTraceExtractor)}, ctx, `{"severity":"INFO","message":"test","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","trace_sampled":true,"requestId":"abc","key":1}`},
+		{"success(CloudLogging)", []Option{WithTraceExtractor(testTraceExtractor), WithCloudLoggingTraceFieldKeys("my-project")}, ctx, `{"severity":"INFO","message":"test","logging.googleapis.com/trace":"projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736","logging.googleapis.com/spanId":"00f067aa0ba902b7","logging.googleapis.com/trace_sampled":true,"requestId":"abc","key":1}`},
+		{"success(OmitKeys)", []Option{WithTraceExtractor(testTraceExtractor), WithTraceFieldKeys("traceId", "", "")}, ctx, `{"severity":"INFO","message":"test","traceId":"4bf92f3577b34da6a3ce929d0e0e4736","requestId":"abc","key":1}`},
+		{"success(NoTrace)", []Option{WithTraceExtractor(testTraceExtractor)}, context.Background(), `{"severity":"INFO","message":"test","key":1}`},
+		{"success(NoExtractor)", nil, ctx, `{"severity":"INFO","message":"test","requestId":"abc","key":1}`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := bytes.NewBuffer(nil)
+			l := Must(New(buf, append([]Option{WithUseTimestampField(false), WithUseCallerField(false)}, tt.options...)...))
+			l.InfoContext(tt.ctx, "test", Int("key", 1))
+
+			FailIfNotEqual(t, tt.expect+defaultLineSeparator, buf.String())
+		})
+	}
+}